@@ -0,0 +1,143 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+//+build !386
+
+package aes
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// ISA-L_crypto has no ECB mode, so ECB runs the expanded encryption keys
+// from the ISA-L key expansion (the standard FIPS-197 round keys) through
+// AES-NI directly. Decryption derives the equivalent inverse cipher keys
+// on each call rather than relying on the layout of ISA-L's decryption
+// keys.
+
+/*
+#include <stdint.h>
+#include <wmmintrin.h>
+
+// ecb_enc encrypts len bytes, whole blocks, with the rounds + 1 round
+// keys, four blocks at a time to fill the pipeline.
+__attribute__((target("aes,sse2")))
+static void ecb_enc(const uint8_t *keys, int rounds, const uint8_t *in, uint8_t *out, uint64_t len) {
+	__m128i k[15];
+	uint64_t i, n = len / 16;
+	int r;
+
+	for (r = 0; r <= rounds; r++)
+		k[r] = _mm_loadu_si128((const __m128i *)(keys + 16 * r));
+
+	for (i = 0; i + 4 <= n; i += 4) {
+		__m128i b0 = _mm_xor_si128(_mm_loadu_si128((const __m128i *)(in + 16 * i)), k[0]);
+		__m128i b1 = _mm_xor_si128(_mm_loadu_si128((const __m128i *)(in + 16 * i + 16)), k[0]);
+		__m128i b2 = _mm_xor_si128(_mm_loadu_si128((const __m128i *)(in + 16 * i + 32)), k[0]);
+		__m128i b3 = _mm_xor_si128(_mm_loadu_si128((const __m128i *)(in + 16 * i + 48)), k[0]);
+		for (r = 1; r < rounds; r++) {
+			b0 = _mm_aesenc_si128(b0, k[r]);
+			b1 = _mm_aesenc_si128(b1, k[r]);
+			b2 = _mm_aesenc_si128(b2, k[r]);
+			b3 = _mm_aesenc_si128(b3, k[r]);
+		}
+		_mm_storeu_si128((__m128i *)(out + 16 * i), _mm_aesenclast_si128(b0, k[rounds]));
+		_mm_storeu_si128((__m128i *)(out + 16 * i + 16), _mm_aesenclast_si128(b1, k[rounds]));
+		_mm_storeu_si128((__m128i *)(out + 16 * i + 32), _mm_aesenclast_si128(b2, k[rounds]));
+		_mm_storeu_si128((__m128i *)(out + 16 * i + 48), _mm_aesenclast_si128(b3, k[rounds]));
+	}
+	for (; i < n; i++) {
+		__m128i b = _mm_xor_si128(_mm_loadu_si128((const __m128i *)(in + 16 * i)), k[0]);
+		for (r = 1; r < rounds; r++)
+			b = _mm_aesenc_si128(b, k[r]);
+		_mm_storeu_si128((__m128i *)(out + 16 * i), _mm_aesenclast_si128(b, k[rounds]));
+	}
+}
+
+// ecb_dec decrypts with the equivalent inverse cipher, whose round keys
+// are the encryption ones reversed and, but for the first and last,
+// through InvMixColumns.
+__attribute__((target("aes,sse2")))
+static void ecb_dec(const uint8_t *keys, int rounds, const uint8_t *in, uint8_t *out, uint64_t len) {
+	__m128i k[15];
+	uint64_t i, n = len / 16;
+	int r;
+
+	k[0] = _mm_loadu_si128((const __m128i *)(keys + 16 * rounds));
+	for (r = 1; r < rounds; r++)
+		k[r] = _mm_aesimc_si128(_mm_loadu_si128((const __m128i *)(keys + 16 * (rounds - r))));
+	k[rounds] = _mm_loadu_si128((const __m128i *)keys);
+
+	for (i = 0; i + 4 <= n; i += 4) {
+		__m128i b0 = _mm_xor_si128(_mm_loadu_si128((const __m128i *)(in + 16 * i)), k[0]);
+		__m128i b1 = _mm_xor_si128(_mm_loadu_si128((const __m128i *)(in + 16 * i + 16)), k[0]);
+		__m128i b2 = _mm_xor_si128(_mm_loadu_si128((const __m128i *)(in + 16 * i + 32)), k[0]);
+		__m128i b3 = _mm_xor_si128(_mm_loadu_si128((const __m128i *)(in + 16 * i + 48)), k[0]);
+		for (r = 1; r < rounds; r++) {
+			b0 = _mm_aesdec_si128(b0, k[r]);
+			b1 = _mm_aesdec_si128(b1, k[r]);
+			b2 = _mm_aesdec_si128(b2, k[r]);
+			b3 = _mm_aesdec_si128(b3, k[r]);
+		}
+		_mm_storeu_si128((__m128i *)(out + 16 * i), _mm_aesdeclast_si128(b0, k[rounds]));
+		_mm_storeu_si128((__m128i *)(out + 16 * i + 16), _mm_aesdeclast_si128(b1, k[rounds]));
+		_mm_storeu_si128((__m128i *)(out + 16 * i + 32), _mm_aesdeclast_si128(b2, k[rounds]));
+		_mm_storeu_si128((__m128i *)(out + 16 * i + 48), _mm_aesdeclast_si128(b3, k[rounds]));
+	}
+	for (; i < n; i++) {
+		__m128i b = _mm_xor_si128(_mm_loadu_si128((const __m128i *)(in + 16 * i)), k[0]);
+		for (r = 1; r < rounds; r++)
+			b = _mm_aesdec_si128(b, k[r]);
+		_mm_storeu_si128((__m128i *)(out + 16 * i), _mm_aesdeclast_si128(b, k[rounds]));
+	}
+}
+*/
+import "C"
+
+// isalECB runs src, whole blocks, through AES with expkeyEnc, an
+// expanded encryption key of the given number of rounds, into dst. dst
+// and src may overlap exactly.
+func isalECB(expkeyEnc []byte, rounds int, dst, src []byte, encrypt bool) error {
+	// Like CBC, the C code has no bounds checks
+	if len(src)%BlockSize != 0 {
+		return errors.New("Input not full blocks")
+	}
+	if len(dst) < len(src) {
+		return errors.New("Destination buffer too small")
+	}
+	if len(src) == 0 {
+		return nil
+	}
+
+	keyPtr := (*C.uint8_t)(unsafe.Pointer(&expkeyEnc[0]))
+	srcPtr := (*C.uint8_t)(unsafe.Pointer(&src[0]))
+	dstPtr := (*C.uint8_t)(unsafe.Pointer(&dst[0]))
+
+	if encrypt {
+		C.ecb_enc(keyPtr, C.int(rounds), srcPtr, dstPtr, C.uint64_t(len(src)))
+	} else {
+		C.ecb_dec(keyPtr, C.int(rounds), srcPtr, dstPtr, C.uint64_t(len(src)))
+	}
+
+	return nil
+}
@@ -57,6 +57,8 @@ var _ cipher.Block = &isal128Cipher{}
 
 func (a *isal128Cipher) Encrypt(cipherText, plainText []byte, mode int) error {
 	switch mode {
+	case cipher.ModeECB:
+		return isalECB(a.expkeyEnc[:], 10, cipherText, plainText, true)
 	case cipher.ModeCBC:
 		encPtr := (*C.uint8_t)(unsafe.Pointer(&a.expkeyEnc[0]))
 		ivPtr := (*C.uint8_t)(unsafe.Pointer(&a.iv[0]))
@@ -95,6 +97,8 @@ func (a *isal128Cipher) Encrypt(cipherText, plainText []byte, mode int) error {
 
 func (a *isal128Cipher) Decrypt(plainText, cipherText []byte, mode int) error {
 	switch mode {
+	case cipher.ModeECB:
+		return isalECB(a.expkeyEnc[:], 10, plainText, cipherText, false)
 	case cipher.ModeCBC:
 		decPtr := (*C.uint8_t)(unsafe.Pointer(&a.expkeyDec[0]))
 		ivPtr := (*C.uint8_t)(unsafe.Pointer(&a.iv[0]))
@@ -180,6 +184,8 @@ var _ cipher.Block = &isal192Cipher{}
 
 func (a *isal192Cipher) Encrypt(cipherText, plainText []byte, mode int) error {
 	switch mode {
+	case cipher.ModeECB:
+		return isalECB(a.expkeyEnc[:], 12, cipherText, plainText, true)
 	case cipher.ModeCBC:
 		encPtr := (*C.uint8_t)(unsafe.Pointer(&a.expkeyEnc[0]))
 		ivPtr := (*C.uint8_t)(unsafe.Pointer(&a.iv[0]))
@@ -198,6 +204,8 @@ func (a *isal192Cipher) Encrypt(cipherText, plainText []byte, mode int) error {
 
 func (a *isal192Cipher) Decrypt(plainText, cipherText []byte, mode int) error {
 	switch mode {
+	case cipher.ModeECB:
+		return isalECB(a.expkeyEnc[:], 12, plainText, cipherText, false)
 	case cipher.ModeCBC:
 		decPtr := (*C.uint8_t)(unsafe.Pointer(&a.expkeyDec[0]))
 		ivPtr := (*C.uint8_t)(unsafe.Pointer(&a.iv[0]))
@@ -261,6 +269,8 @@ var _ cipher.Block = &isal256Cipher{}
 
 func (a *isal256Cipher) Encrypt(cipherText, plainText []byte, mode int) error {
 	switch mode {
+	case cipher.ModeECB:
+		return isalECB(a.expkeyEnc[:], 14, cipherText, plainText, true)
 	case cipher.ModeCBC:
 		encPtr := (*C.uint8_t)(unsafe.Pointer(&a.expkeyEnc[0]))
 		ivPtr := (*C.uint8_t)(unsafe.Pointer(&a.iv[0]))
@@ -309,6 +319,8 @@ func (a *isal256Cipher) Encrypt(cipherText, plainText []byte, mode int) error {
 
 func (a *isal256Cipher) Decrypt(plainText, cipherText []byte, mode int) error {
 	switch mode {
+	case cipher.ModeECB:
+		return isalECB(a.expkeyEnc[:], 14, plainText, cipherText, false)
 	case cipher.ModeCBC:
 		decPtr := (*C.uint8_t)(unsafe.Pointer(&a.expkeyDec[0]))
 		ivPtr := (*C.uint8_t)(unsafe.Pointer(&a.iv[0]))
@@ -0,0 +1,435 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cavp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// acvpHeader is the first element of a downloaded ACVP request or an
+// uploaded response.
+type acvpHeader struct {
+	ACVVersion string `json:"acvVersion"`
+}
+
+type acvpRequest struct {
+	VsID       int             `json:"vsId"`
+	Algorithm  string          `json:"algorithm"`
+	Revision   string          `json:"revision"`
+	IsSample   bool            `json:"isSample"`
+	TestGroups []acvpTestGroup `json:"testGroups"`
+}
+
+type acvpTestGroup struct {
+	TgID       int            `json:"tgId"`
+	TestType   string         `json:"testType"`
+	Direction  string         `json:"direction"`
+	KeyLen     int            `json:"keyLen"`
+	IVLen      int            `json:"ivLen"`
+	IVGen      string         `json:"ivGen"`
+	PayloadLen int            `json:"payloadLen"`
+	TagLen     int            `json:"tagLen"`
+	TweakMode  string         `json:"tweakMode"`
+	Tests      []acvpTestCase `json:"tests"`
+}
+
+type acvpTestCase struct {
+	TcID           int    `json:"tcId"`
+	Key            string `json:"key"`
+	IV             string `json:"iv"`
+	PT             string `json:"pt"`
+	CT             string `json:"ct"`
+	AAD            string `json:"aad"`
+	Tag            string `json:"tag"`
+	TweakValue     string `json:"tweakValue"`
+	SequenceNumber uint64 `json:"sequenceNumber"`
+	DataUnitLen    int    `json:"dataUnitLen"`
+}
+
+// acvpResponse is the response to one vector set. Outputs are pointers
+// so that an empty plaintext is still reported.
+type acvpResponse struct {
+	VsID       int                 `json:"vsId"`
+	Algorithm  string              `json:"algorithm"`
+	Revision   string              `json:"revision"`
+	TestGroups []acvpResponseGroup `json:"testGroups"`
+}
+
+type acvpResponseGroup struct {
+	TgID  int                `json:"tgId"`
+	Tests []acvpResponseCase `json:"tests"`
+}
+
+type acvpResponseCase struct {
+	TcID         int          `json:"tcId"`
+	PT           *string      `json:"pt,omitempty"`
+	CT           *string      `json:"ct,omitempty"`
+	Tag          *string      `json:"tag,omitempty"`
+	TestPassed   *bool        `json:"testPassed,omitempty"`
+	ResultsArray []acvpMCTRow `json:"resultsArray,omitempty"`
+}
+
+type acvpMCTRow struct {
+	Key string `json:"key"`
+	IV  string `json:"iv"`
+	PT  string `json:"pt"`
+	CT  string `json:"ct"`
+}
+
+// ACVP encodes binary values as upper case hex
+func acvpHex(b []byte) *string {
+	s := strings.ToUpper(hex.EncodeToString(b))
+	return &s
+}
+
+// splitACVP separates the version header from the vector set. Both the
+// downloaded layout, [{"acvVersion": ...}, {...}], and a bare vector set
+// are accepted.
+func splitACVP(data []byte) (*acvpHeader, json.RawMessage, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '[' {
+		return nil, data, nil
+	}
+
+	var parts []json.RawMessage
+	if err := json.Unmarshal(data, &parts); err != nil {
+		return nil, nil, err
+	}
+	if len(parts) != 2 {
+		return nil, nil, errors.New("cavp: expected version header and vector set")
+	}
+	hdr := new(acvpHeader)
+	if err := json.Unmarshal(parts[0], hdr); err != nil {
+		return nil, nil, err
+	}
+
+	return hdr, parts[1], nil
+}
+
+// ProcessACVP reads an ACVP vector set for ACVP-AES-CBC, ACVP-AES-GCM or
+// ACVP-AES-XTS and writes the response in the same layout. Test groups
+// with parameters this module does not implement are left out of the
+// response and counted as skipped.
+func ProcessACVP(r io.Reader, w io.Writer) (*Result, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	hdr, body, err := splitACVP(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var req acvpRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, err
+	}
+
+	var run func(*acvpTestGroup, *acvpTestCase) (*acvpResponseCase, error)
+	switch req.Algorithm {
+	case "ACVP-AES-CBC":
+		run = acvpCBC
+	case "ACVP-AES-GCM":
+		run = acvpGCM
+	case "ACVP-AES-XTS":
+		run = acvpXTS
+	default:
+		return nil, fmt.Errorf("cavp: unsupported algorithm %q", req.Algorithm)
+	}
+
+	res := new(Result)
+	resp := acvpResponse{VsID: req.VsID, Algorithm: req.Algorithm, Revision: req.Revision}
+	for i := range req.TestGroups {
+		tg := &req.TestGroups[i]
+		rg := acvpResponseGroup{TgID: tg.TgID}
+		for j := range tg.Tests {
+			rc, err := run(tg, &tg.Tests[j])
+			switch {
+			case err == errUnsupported:
+				res.Skipped++
+			case err != nil:
+				return nil, fmt.Errorf("cavp: tgId %d tcId %d: %v", tg.TgID, tg.Tests[j].TcID, err)
+			default:
+				rc.TcID = tg.Tests[j].TcID
+				rg.Tests = append(rg.Tests, *rc)
+			}
+		}
+		if len(rg.Tests) > 0 {
+			resp.TestGroups = append(resp.TestGroups, rg)
+		}
+	}
+
+	var out interface{} = resp
+	if hdr != nil {
+		out = []interface{}{hdr, resp}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return res, enc.Encode(out)
+}
+
+func acvpEncrypt(tg *acvpTestGroup) (bool, error) {
+	switch tg.Direction {
+	case "encrypt":
+		return true, nil
+	case "decrypt":
+		return false, nil
+	}
+
+	return false, fmt.Errorf("unknown direction %q", tg.Direction)
+}
+
+// acvpDecode decodes the named hex fields of a test case in order.
+func acvpDecode(fields ...string) ([][]byte, error) {
+	out := make([][]byte, len(fields))
+	for i, f := range fields {
+		b, err := hex.DecodeString(f)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = b
+	}
+
+	return out, nil
+}
+
+func acvpCBC(tg *acvpTestGroup, tc *acvpTestCase) (*acvpResponseCase, error) {
+	encrypt, err := acvpEncrypt(tg)
+	if err != nil {
+		return nil, err
+	}
+	in := tc.CT
+	if encrypt {
+		in = tc.PT
+	}
+	f, err := acvpDecode(tc.Key, tc.IV, in)
+	if err != nil {
+		return nil, err
+	}
+	key, iv, input := f[0], f[1], f[2]
+
+	switch tg.TestType {
+	case "AFT":
+		block, err := newBlock(key)
+		if err != nil {
+			return nil, err
+		}
+		mode := cbcMode(block, iv, encrypt)
+		out := make([]byte, len(input))
+		if err := mode.CryptBlocks(out, input); err != nil {
+			return nil, err
+		}
+		if encrypt {
+			return &acvpResponseCase{CT: acvpHex(out)}, nil
+		}
+		return &acvpResponseCase{PT: acvpHex(out)}, nil
+
+	case "MCT":
+		results, err := CBCMonteCarlo(key, iv, input, encrypt, 100)
+		if err != nil {
+			return nil, err
+		}
+		rc := new(acvpResponseCase)
+		for _, r := range results {
+			row := acvpMCTRow{Key: *acvpHex(r.Key), IV: *acvpHex(r.IV)}
+			if encrypt {
+				row.PT, row.CT = *acvpHex(r.Input), *acvpHex(r.Output)
+			} else {
+				row.CT, row.PT = *acvpHex(r.Input), *acvpHex(r.Output)
+			}
+			rc.ResultsArray = append(rc.ResultsArray, row)
+		}
+		return rc, nil
+	}
+
+	return nil, fmt.Errorf("unknown test type %q", tg.TestType)
+}
+
+func acvpGCM(tg *acvpTestGroup, tc *acvpTestCase) (*acvpResponseCase, error) {
+	encrypt, err := acvpEncrypt(tg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Internally generated IVs would need the IV returned as well;
+	// only external IVs are supported.
+	if tg.IVGen != "" && tg.IVGen != "external" {
+		return nil, errUnsupported
+	}
+
+	if encrypt {
+		f, err := acvpDecode(tc.Key, tc.IV, tc.PT, tc.AAD)
+		if err != nil {
+			return nil, err
+		}
+		ct, tag, err := GCMSeal(f[0], f[1], f[2], f[3], tg.TagLen/8)
+		if err != nil {
+			return nil, err
+		}
+		return &acvpResponseCase{CT: acvpHex(ct), Tag: acvpHex(tag)}, nil
+	}
+
+	f, err := acvpDecode(tc.Key, tc.IV, tc.CT, tc.AAD, tc.Tag)
+	if err != nil {
+		return nil, err
+	}
+	pt, ok, err := GCMOpen(f[0], f[1], f[2], f[3], f[4])
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		passed := false
+		return &acvpResponseCase{TestPassed: &passed}, nil
+	}
+
+	return &acvpResponseCase{PT: acvpHex(pt)}, nil
+}
+
+func acvpXTS(tg *acvpTestGroup, tc *acvpTestCase) (*acvpResponseCase, error) {
+	encrypt, err := acvpEncrypt(tg)
+	if err != nil {
+		return nil, err
+	}
+	if tc.DataUnitLen%8 != 0 || tg.PayloadLen%8 != 0 {
+		return nil, errUnsupported
+	}
+
+	in := tc.CT
+	if encrypt {
+		in = tc.PT
+	}
+	f, err := acvpDecode(tc.Key, in)
+	if err != nil {
+		return nil, err
+	}
+
+	// A numeric tweak is the data unit sequence number, little endian
+	var tweak []byte
+	switch tg.TweakMode {
+	case "hex":
+		if tweak, err = hex.DecodeString(tc.TweakValue); err != nil {
+			return nil, err
+		}
+	case "number":
+		tweak = make([]byte, 16)
+		binary.LittleEndian.PutUint64(tweak, tc.SequenceNumber)
+	default:
+		return nil, fmt.Errorf("unknown tweak mode %q", tg.TweakMode)
+	}
+
+	out, err := XTSCrypt(f[0], tweak, f[1], encrypt)
+	if err != nil {
+		return nil, err
+	}
+	if encrypt {
+		return &acvpResponseCase{CT: acvpHex(out)}, nil
+	}
+
+	return &acvpResponseCase{PT: acvpHex(out)}, nil
+}
+
+// CheckACVP compares a response produced by ProcessACVP with the
+// expected results of a sample vector set.
+func CheckACVP(got, want io.Reader) (*Result, error) {
+	var resp [2]acvpResponse
+	for i, r := range []io.Reader{got, want} {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		_, body, err := splitACVP(data)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(body, &resp[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	have := map[[2]int]acvpResponseCase{}
+	for _, g := range resp[0].TestGroups {
+		for _, tc := range g.Tests {
+			have[[2]int{g.TgID, tc.TcID}] = tc
+		}
+	}
+
+	res := new(Result)
+	for _, g := range resp[1].TestGroups {
+		for _, tc := range g.Tests {
+			where := fmt.Sprintf("tgId %d tcId %d", g.TgID, tc.TcID)
+			h, ok := have[[2]int{g.TgID, tc.TcID}]
+			if !ok {
+				res.Failed++
+				res.Failures = append(res.Failures, where+": missing")
+				continue
+			}
+			if !sameCase(&h, &tc) {
+				res.Failed++
+				hj, _ := json.Marshal(h)
+				wj, _ := json.Marshal(tc)
+				res.Failures = append(res.Failures, fmt.Sprintf("%s: have %s, want %s", where, hj, wj))
+				continue
+			}
+			res.Passed++
+		}
+	}
+
+	return res, nil
+}
+
+func sameString(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return strings.EqualFold(*a, *b)
+}
+
+func sameCase(a, b *acvpResponseCase) bool {
+	if !sameString(a.PT, b.PT) || !sameString(a.CT, b.CT) || !sameString(a.Tag, b.Tag) {
+		return false
+	}
+	if (a.TestPassed == nil) != (b.TestPassed == nil) || (a.TestPassed != nil && *a.TestPassed != *b.TestPassed) {
+		return false
+	}
+	if len(a.ResultsArray) != len(b.ResultsArray) {
+		return false
+	}
+	for i := range a.ResultsArray {
+		x, y := a.ResultsArray[i], b.ResultsArray[i]
+		if !strings.EqualFold(x.Key, y.Key) || !strings.EqualFold(x.IV, y.IV) ||
+			!strings.EqualFold(x.PT, y.PT) || !strings.EqualFold(x.CT, y.CT) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,558 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package cavp runs the NIST CAVP (.req/.rsp) and ACVP (JSON) test
+// formats for the AES modes of this module: CBC (including Monte Carlo
+// tests), GCM and XTS. It backs both the conformance tests and the cavp
+// command, which writes response files for submission.
+package cavp
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cipher"
+)
+
+// Result summarizes a run. Records with expected values are counted as
+// passed or failed; records whose parameters are not supported by this
+// module, such as GCM-192 or non 96-bit IVs, are skipped.
+type Result struct {
+	Passed, Failed, Skipped int
+	Failures                []string
+}
+
+func (r *Result) String() string {
+	return fmt.Sprintf("passed %d, failed %d, skipped %d", r.Passed, r.Failed, r.Skipped)
+}
+
+var errUnsupported = errors.New("cavp: unsupported parameters")
+
+// The CAVP file kinds, derived from the NIST file names.
+const (
+	kindCBC        = "CBC"
+	kindCBCMCT     = "CBCMCT"
+	kindGCMEncrypt = "gcmEncryptExtIV"
+	kindGCMDecrypt = "gcmDecrypt"
+	kindXTS        = "XTSGenAES"
+)
+
+func kindOf(name string) (string, error) {
+	base := filepath.Base(name)
+	for _, k := range []string{kindCBCMCT, kindCBC, kindGCMEncrypt, kindGCMDecrypt, kindXTS} {
+		if strings.HasPrefix(base, k) {
+			return k, nil
+		}
+	}
+
+	return "", fmt.Errorf("cavp: unknown test file %q", base)
+}
+
+// Process computes the responses for the CAVP file with the given file
+// name, which selects the test type as in the NIST file set, e.g.
+// CBCGFSbox128.rsp, CBCMCT256.req, gcmDecrypt128.rsp or XTSGenAES256.rsp.
+// The returned file has every output filled in. Where the input already
+// carries outputs (a .rsp file), they are compared with the computed
+// ones and mismatches are reported in the Result.
+func Process(name string, in *File) (*File, *Result, error) {
+	kind, err := kindOf(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out := &File{Header: in.Header}
+	res := new(Result)
+
+	for _, sec := range in.Sections {
+		osec := Section{Names: sec.Names, Params: sec.Params}
+		for _, rec := range sec.Records {
+			orec := Record{Fields: append([]Field(nil), rec.Fields...)}
+
+			var outputs []Field
+			switch kind {
+			case kindCBC:
+				outputs, err = cbcRecord(&sec, &rec)
+			case kindCBCMCT:
+				outputs, err = cbcMCTRecord(&sec, &rec)
+			case kindGCMEncrypt:
+				outputs, err = gcmEncryptRecord(&sec, &rec)
+			case kindGCMDecrypt:
+				outputs, err = gcmDecryptRecord(&sec, &rec)
+			case kindXTS:
+				outputs, err = xtsRecord(&sec, &rec)
+			}
+
+			count, _ := rec.Get("COUNT")
+			switch {
+			case err == errUnsupported:
+				res.Skipped++
+			case err != nil:
+				return nil, nil, fmt.Errorf("%s: COUNT = %s: %v", name, count, err)
+			default:
+				where := fmt.Sprintf("%s %v COUNT = %s", name, sectionString(&sec), count)
+				check(res, &rec, &orec, outputs, where)
+			}
+
+			osec.Records = append(osec.Records, orec)
+		}
+		out.Sections = append(out.Sections, osec)
+	}
+
+	return out, res, nil
+}
+
+func sectionString(sec *Section) []string {
+	s := append([]string(nil), sec.Names...)
+	for _, p := range sec.Params {
+		s = append(s, p.Name+"="+p.Value)
+	}
+
+	return s
+}
+
+// check compares computed outputs with any expected ones in rec and
+// stores them in out. A decrypt failure is the lone field FAIL.
+func check(res *Result, rec, out *Record, outputs []Field, where string) {
+	expected := false
+	mismatch := []string{}
+
+	for _, o := range outputs {
+		if o.Name == "FAIL" {
+			_, hadPT := rec.Get("PT")
+			_, hadFail := rec.Get("FAIL")
+			if hadPT || hadFail {
+				expected = true
+				if !hadFail {
+					mismatch = append(mismatch, "FAIL: unexpected authentication failure")
+				}
+			}
+			out.Delete("PT")
+			out.Set("FAIL", "")
+			continue
+		}
+
+		if want, ok := rec.Get(o.Name); ok {
+			expected = true
+			if !strings.EqualFold(want, o.Value) {
+				mismatch = append(mismatch, fmt.Sprintf("%s: have %s, want %s", o.Name, o.Value, want))
+			}
+		} else if _, failed := rec.Get("FAIL"); failed {
+			expected = true
+			mismatch = append(mismatch, fmt.Sprintf("%s: have %s, want FAIL", o.Name, o.Value))
+			out.Delete("FAIL")
+		}
+		out.Set(o.Name, o.Value)
+	}
+
+	switch {
+	case !expected:
+		// request file: nothing to compare against
+	case len(mismatch) == 0:
+		res.Passed++
+	default:
+		res.Failed++
+		for _, m := range mismatch {
+			res.Failures = append(res.Failures, where+": "+m)
+		}
+	}
+}
+
+// field returns a hex field, which may be empty.
+func field(rec *Record, names ...string) ([]byte, error) {
+	for _, name := range names {
+		if v, ok := rec.Get(name); ok {
+			return hex.DecodeString(v)
+		}
+	}
+
+	return nil, fmt.Errorf("missing field %s", names[0])
+}
+
+func direction(sec *Section) (encrypt bool, err error) {
+	switch {
+	case sec.Has("ENCRYPT"):
+		return true, nil
+	case sec.Has("DECRYPT"):
+		return false, nil
+	}
+
+	return false, errors.New("section is neither ENCRYPT nor DECRYPT")
+}
+
+// newBlock returns errUnsupported for key sizes that CBC and GCM do not
+// take, rather than the error from aes.NewCipher.
+func newBlock(key []byte) (cipher.Block, error) {
+	switch len(key) {
+	case 16, 24, 32:
+		return aes.NewCipher(key)
+	}
+
+	return nil, errUnsupported
+}
+
+func cbcMode(block cipher.Block, iv []byte, encrypt bool) cipher.BlockMode {
+	if encrypt {
+		return cipher.NewCBCEncrypter(block, iv)
+	}
+
+	return cipher.NewCBCDecrypter(block, iv)
+}
+
+func cbcRecord(sec *Section, rec *Record) ([]Field, error) {
+	encrypt, err := direction(sec)
+	if err != nil {
+		return nil, err
+	}
+	key, err := field(rec, "KEY")
+	if err != nil {
+		return nil, err
+	}
+	iv, err := field(rec, "IV")
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := newBlock(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if encrypt {
+		pt, err := field(rec, "PLAINTEXT")
+		if err != nil {
+			return nil, err
+		}
+		ct := make([]byte, len(pt))
+		if err := cbcMode(block, iv, true).CryptBlocks(ct, pt); err != nil {
+			return nil, err
+		}
+		return []Field{{"CIPHERTEXT", hex.EncodeToString(ct)}}, nil
+	}
+
+	ct, err := field(rec, "CIPHERTEXT")
+	if err != nil {
+		return nil, err
+	}
+	pt := make([]byte, len(ct))
+	if err := cbcMode(block, iv, false).CryptBlocks(pt, ct); err != nil {
+		return nil, err
+	}
+
+	return []Field{{"PLAINTEXT", hex.EncodeToString(pt)}}, nil
+}
+
+// MCTResult is one outer iteration of a Monte Carlo test: the inputs it
+// started from and the final output of its 1000 inner iterations.
+type MCTResult struct {
+	Key, IV, Input, Output []byte
+}
+
+// CBCMonteCarlo runs the AESAVS / ACVP Monte Carlo test for CBC for the
+// given number of outer iterations (100 for a full test).
+func CBCMonteCarlo(key, iv, input []byte, encrypt bool, rounds int) ([]MCTResult, error) {
+	results := make([]MCTResult, 0, rounds)
+	key = append([]byte(nil), key...)
+	iv = append([]byte(nil), iv...)
+	input = append([]byte(nil), input...)
+
+	for i := 0; i < rounds; i++ {
+		out, prev, err := cbcMCTRound(key, iv, input, encrypt)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, MCTResult{
+			Key:    append([]byte(nil), key...),
+			IV:     append([]byte(nil), iv...),
+			Input:  append([]byte(nil), input...),
+			Output: out,
+		})
+
+		// Key[i+1] = Key[i] xor the last len(key) bytes of out[998] || out[999]
+		tail := append(append([]byte(nil), prev...), out...)
+		tail = tail[len(tail)-len(key):]
+		for j := range key {
+			key[j] ^= tail[j]
+		}
+		iv = out
+		input = prev
+	}
+
+	return results, nil
+}
+
+// cbcMCTRound runs the 1000 inner iterations of one outer iteration and
+// returns the last two outputs.
+func cbcMCTRound(key, iv, input []byte, encrypt bool) (out, prev []byte, err error) {
+	block, err := newBlock(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	mode := cbcMode(block, iv, encrypt)
+
+	chain := iv
+	for j := 0; j < 1000; j++ {
+		o := make([]byte, aes.BlockSize)
+		mode.SetIV(chain)
+		if err := mode.CryptBlocks(o, input); err != nil {
+			return nil, nil, err
+		}
+
+		// CBC chains on the ciphertext: the output when encrypting
+		// and the input when decrypting.
+		if encrypt {
+			chain = o
+		} else {
+			chain = input
+		}
+
+		// The next input is the IV, then the output two steps back
+		if j == 0 {
+			input = iv
+		} else {
+			input = out
+		}
+		prev, out = out, o
+	}
+
+	return out, prev, nil
+}
+
+func cbcMCTRecord(sec *Section, rec *Record) ([]Field, error) {
+	encrypt, err := direction(sec)
+	if err != nil {
+		return nil, err
+	}
+	key, err := field(rec, "KEY")
+	if err != nil {
+		return nil, err
+	}
+	iv, err := field(rec, "IV")
+	if err != nil {
+		return nil, err
+	}
+
+	inName, outName := "CIPHERTEXT", "PLAINTEXT"
+	if encrypt {
+		inName, outName = outName, inName
+	}
+	input, err := field(rec, inName)
+	if err != nil {
+		return nil, err
+	}
+
+	out, _, err := cbcMCTRound(key, iv, input, encrypt)
+	if err != nil {
+		return nil, err
+	}
+
+	return []Field{{outName, hex.EncodeToString(out)}}, nil
+}
+
+func intParam(sec *Section, name string) (int, error) {
+	v, ok := sec.Param(name)
+	if !ok {
+		return 0, fmt.Errorf("missing parameter %s", name)
+	}
+
+	return strconv.Atoi(v)
+}
+
+// gcmBlock returns a block for GCM or errUnsupported for parameters
+// this module does not implement: GCM-192 and IVs other than 96 bits.
+func gcmBlock(key, iv []byte) (cipher.Block, error) {
+	if len(iv) != 12 || len(key) == 24 {
+		return nil, errUnsupported
+	}
+
+	return newBlock(key)
+}
+
+// GCMSeal encrypts with GCM and returns the ciphertext and the tag
+// truncated to tagLen bytes.
+func GCMSeal(key, iv, pt, aad []byte, tagLen int) (ct, tag []byte, err error) {
+	block, err := gcmBlock(key, iv)
+	if err != nil {
+		return nil, nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out := aead.Seal(nil, iv, pt, aad)
+
+	return out[:len(pt)], out[len(pt) : len(pt)+tagLen], nil
+}
+
+// GCMOpen decrypts with GCM and checks a tag of any length up to 16
+// bytes. The AEAD interface only takes full tags, so this goes to the
+// Block directly.
+func GCMOpen(key, iv, ct, aad, tag []byte) (pt []byte, ok bool, err error) {
+	block, err := gcmBlock(key, iv)
+	if err != nil {
+		return nil, false, err
+	}
+
+	pt = make([]byte, len(ct))
+	block.SetIV(iv)
+	block.GCMAddAdditionalData(aad)
+	if err := block.Decrypt(pt, ct, cipher.ModeGCM); err != nil {
+		return nil, false, err
+	}
+	computed := block.GCMGetAuthTag()
+	if len(tag) > len(computed) || subtle.ConstantTimeCompare(computed[:len(tag)], tag) != 1 {
+		return nil, false, nil
+	}
+
+	return pt, true, nil
+}
+
+func gcmFields(rec *Record) (key, iv, aad []byte, err error) {
+	if key, err = field(rec, "Key"); err != nil {
+		return
+	}
+	if iv, err = field(rec, "IV"); err != nil {
+		return
+	}
+	aad, err = field(rec, "AAD")
+	return
+}
+
+func gcmEncryptRecord(sec *Section, rec *Record) ([]Field, error) {
+	tagBits, err := intParam(sec, "Taglen")
+	if err != nil {
+		return nil, err
+	}
+	key, iv, aad, err := gcmFields(rec)
+	if err != nil {
+		return nil, err
+	}
+	pt, err := field(rec, "PT")
+	if err != nil {
+		return nil, err
+	}
+
+	ct, tag, err := GCMSeal(key, iv, pt, aad, tagBits/8)
+	if err != nil {
+		return nil, err
+	}
+
+	return []Field{{"CT", hex.EncodeToString(ct)}, {"Tag", hex.EncodeToString(tag)}}, nil
+}
+
+func gcmDecryptRecord(sec *Section, rec *Record) ([]Field, error) {
+	key, iv, aad, err := gcmFields(rec)
+	if err != nil {
+		return nil, err
+	}
+	ct, err := field(rec, "CT")
+	if err != nil {
+		return nil, err
+	}
+	tag, err := field(rec, "Tag")
+	if err != nil {
+		return nil, err
+	}
+
+	pt, ok, err := GCMOpen(key, iv, ct, aad, tag)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return []Field{{Name: "FAIL"}}, nil
+	}
+
+	return []Field{{"PT", hex.EncodeToString(pt)}}, nil
+}
+
+// XTSCrypt encrypts or decrypts a data unit with AES-XTS. key holds both
+// halves of the XTS key and tweak is the 16 byte tweak.
+func XTSCrypt(key, tweak, in []byte, encrypt bool) ([]byte, error) {
+	if len(key) != 32 && len(key) != 64 {
+		return nil, errUnsupported
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	x := cipher.NewXTSEncryptor(block)
+	x.SetIV(tweak)
+
+	out := make([]byte, len(in))
+	if encrypt {
+		err = x.Encrypt(out, in)
+	} else {
+		err = x.Decrypt(out, in)
+	}
+
+	return out, err
+}
+
+func xtsRecord(sec *Section, rec *Record) ([]Field, error) {
+	encrypt, err := direction(sec)
+	if err != nil {
+		return nil, err
+	}
+
+	// Data units that are not a whole number of bytes are not supported
+	if v, ok := rec.Get("DataUnitLen"); ok {
+		bits, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, err
+		}
+		if bits%8 != 0 {
+			return nil, errUnsupported
+		}
+	}
+
+	key, err := field(rec, "Key")
+	if err != nil {
+		return nil, err
+	}
+	tweak, err := field(rec, "i")
+	if err != nil {
+		return nil, err
+	}
+
+	inName, outName := "CT", "PT"
+	if encrypt {
+		inName, outName = outName, inName
+	}
+	in, err := field(rec, inName)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := XTSCrypt(key, tweak, in, encrypt)
+	if err != nil {
+		return nil, err
+	}
+
+	return []Field{{outName, hex.EncodeToString(out)}}, nil
+}
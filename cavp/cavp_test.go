@@ -0,0 +1,216 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cavp_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cavp"
+)
+
+func parseFile(t *testing.T, name string) *cavp.File {
+	t.Helper()
+
+	fd, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+
+	f, err := cavp.Parse(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return f
+}
+
+func TestCAVP(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	names, err := filepath.Glob("testdata/*.rsp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range names {
+		_, res, err := cavp.Process(name, parseFile(t, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, f := range res.Failures {
+			t.Error(f)
+		}
+		if res.Passed == 0 {
+			t.Errorf("%s: no records checked", name)
+		}
+		t.Logf("%s: %v", filepath.Base(name), res)
+	}
+}
+
+// supported drops the GCM sections with IVs other than 96 bits, which
+// Process skips and so leaves without outputs.
+func supported(f *cavp.File) *cavp.File {
+	var secs []cavp.Section
+	for _, sec := range f.Sections {
+		if iv, ok := sec.Param("IVlen"); ok && iv != "96" {
+			continue
+		}
+		secs = append(secs, sec)
+	}
+	f.Sections = secs
+
+	return f
+}
+
+// Stripping the outputs from a response file gives a request file, and
+// processing that must reproduce the response, FAIL lines included.
+func TestCAVPRequest(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	for _, tc := range []struct {
+		name             string
+		encrypt, decrypt []string
+	}{
+		{"CBCGFSbox128.rsp", []string{"CIPHERTEXT"}, []string{"PLAINTEXT"}},
+		{"CBCMCT128.rsp", []string{"CIPHERTEXT"}, []string{"PLAINTEXT"}},
+		{"gcmEncryptExtIV128.rsp", []string{"CT", "Tag"}, nil},
+		{"gcmDecrypt128.rsp", nil, []string{"PT", "FAIL"}},
+		{"XTSGenAES256.rsp", []string{"CT"}, []string{"PT"}},
+	} {
+		path := filepath.Join("testdata", tc.name)
+		want := supported(parseFile(t, path))
+		req := supported(parseFile(t, path))
+
+		for i := range req.Sections {
+			sec := &req.Sections[i]
+			outputs := tc.encrypt
+			if sec.Has("DECRYPT") || strings.HasPrefix(tc.name, "gcmDecrypt") {
+				outputs = tc.decrypt
+			}
+			for j := range sec.Records {
+				for _, o := range outputs {
+					sec.Records[j].Delete(o)
+				}
+			}
+		}
+
+		got, _, err := cavp.Process(tc.name, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var gotBuf, wantBuf bytes.Buffer
+		if err := got.Write(&gotBuf); err != nil {
+			t.Fatal(err)
+		}
+		if err := want.Write(&wantBuf); err != nil {
+			t.Fatal(err)
+		}
+		if gotBuf.String() != wantBuf.String() {
+			t.Errorf("%s: response differs:\n%s", tc.name, gotBuf.String())
+		}
+	}
+}
+
+func TestCAVPMismatch(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	for _, name := range []string{"CBCGFSbox128.rsp", "gcmDecrypt128.rsp"} {
+		f := parseFile(t, filepath.Join("testdata", name))
+
+		// Flip the expected output of the first record: a CIPHERTEXT
+		// digit, or a PT replaced by FAIL.
+		rec := &f.Sections[0].Records[0]
+		if v, ok := rec.Get("CIPHERTEXT"); ok {
+			rec.Set("CIPHERTEXT", "ff"+v[2:])
+		} else {
+			rec.Delete("PT")
+			rec.Set("FAIL", "")
+		}
+
+		_, res, err := cavp.Process(name, f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Failed != 1 {
+			t.Errorf("%s: %d failures, want 1", name, res.Failed)
+		}
+	}
+}
+
+func TestACVP(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	names, err := filepath.Glob("testdata/acvp/ACVP-*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range names {
+		if strings.HasSuffix(name, ".expected.json") {
+			continue
+		}
+
+		req, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var resp bytes.Buffer
+		ran, err := cavp.ProcessACVP(req, &resp)
+		req.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want, err := os.Open(strings.TrimSuffix(name, ".json") + ".expected.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := cavp.CheckACVP(&resp, want)
+		want.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Skipped = ran.Skipped
+
+		for _, f := range res.Failures {
+			t.Error(f)
+		}
+		if res.Passed == 0 {
+			t.Errorf("%s: no tests checked", name)
+		}
+		t.Logf("%s: %v", filepath.Base(name), res)
+	}
+}
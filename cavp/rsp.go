@@ -0,0 +1,210 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cavp
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Field is a single "NAME = value" line of a CAVP record.
+type Field struct {
+	Name, Value string
+}
+
+// Record is a run of fields between blank lines, e.g. one COUNT.
+type Record struct {
+	Fields []Field
+}
+
+// Get returns the value of the named field. Names are matched without
+// regard to case because CAVP files are not consistent about it.
+func (r *Record) Get(name string) (string, bool) {
+	for _, f := range r.Fields {
+		if strings.EqualFold(f.Name, name) {
+			return f.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// Set replaces the value of the named field, adding it if necessary.
+func (r *Record) Set(name, value string) {
+	for i, f := range r.Fields {
+		if strings.EqualFold(f.Name, name) {
+			r.Fields[i].Value = value
+			return
+		}
+	}
+	r.Fields = append(r.Fields, Field{name, value})
+}
+
+// Delete removes the named field.
+func (r *Record) Delete(name string) {
+	for i, f := range r.Fields {
+		if strings.EqualFold(f.Name, name) {
+			r.Fields = append(r.Fields[:i], r.Fields[i+1:]...)
+			return
+		}
+	}
+}
+
+// Section is a group of records sharing bracketed parameters, such as
+// "[ENCRYPT]" or "[Keylen = 128]". Parameters without a value (the
+// direction) are kept in Names.
+type Section struct {
+	Names   []string
+	Params  []Field
+	Records []Record
+}
+
+// Param returns the value of a bracketed parameter.
+func (s *Section) Param(name string) (string, bool) {
+	for _, f := range s.Params {
+		if strings.EqualFold(f.Name, name) {
+			return f.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// Has reports whether the section carries the named bracket, e.g.
+// "ENCRYPT".
+func (s *Section) Has(name string) bool {
+	for _, n := range s.Names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// File is a parsed CAVP request (.req) or response (.rsp) file.
+type File struct {
+	// Header holds the leading comment lines without the "#".
+	Header   []string
+	Sections []Section
+}
+
+// A line outside any record that holds a lone token, such as the
+// "FAIL" of a decrypt test, is kept as a field without a value.
+func splitField(line string) Field {
+	i := strings.IndexByte(line, '=')
+	if i < 0 {
+		return Field{Name: strings.TrimSpace(line)}
+	}
+
+	return Field{strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:])}
+}
+
+// Parse reads a CAVP .req or .rsp file.
+func Parse(r io.Reader) (*File, error) {
+	f := new(File)
+	var sec *Section
+	var rec *Record
+	inParams := false
+
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+
+		switch {
+		case line == "":
+			rec = nil
+		case strings.HasPrefix(line, "#"):
+			if sec == nil {
+				f.Header = append(f.Header, strings.TrimSpace(line[1:]))
+			}
+		case strings.HasPrefix(line, "["):
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("cavp: line %d: unterminated bracket", n)
+			}
+			if sec == nil || !inParams {
+				f.Sections = append(f.Sections, Section{})
+				sec = &f.Sections[len(f.Sections)-1]
+			}
+			inParams = true
+			rec = nil
+
+			p := splitField(line[1 : len(line)-1])
+			if p.Value == "" && !strings.Contains(line, "=") {
+				sec.Names = append(sec.Names, p.Name)
+			} else {
+				sec.Params = append(sec.Params, p)
+			}
+		default:
+			if sec == nil {
+				f.Sections = append(f.Sections, Section{})
+				sec = &f.Sections[len(f.Sections)-1]
+			}
+			inParams = false
+
+			if rec == nil {
+				sec.Records = append(sec.Records, Record{})
+				rec = &sec.Records[len(sec.Records)-1]
+			}
+			rec.Fields = append(rec.Fields, splitField(line))
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// Write emits f in the CAVP file layout.
+func (f *File) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	for _, h := range f.Header {
+		fmt.Fprintf(bw, "# %s\n", h)
+	}
+	for _, sec := range f.Sections {
+		fmt.Fprintln(bw)
+		for _, n := range sec.Names {
+			fmt.Fprintf(bw, "[%s]\n", n)
+		}
+		for _, p := range sec.Params {
+			fmt.Fprintf(bw, "[%s = %s]\n", p.Name, p.Value)
+		}
+		for _, rec := range sec.Records {
+			fmt.Fprintln(bw)
+			for _, field := range rec.Fields {
+				if field.Value == "" && field.Name == "FAIL" {
+					fmt.Fprintln(bw, field.Name)
+					continue
+				}
+				fmt.Fprintf(bw, "%s = %s\n", field.Name, field.Value)
+			}
+		}
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,181 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package cbchmac implements the AES-CBC-HMAC-SHA2 authenticated
+// encryption of draft-mcgrew-aead-aes-cbc-hmac-sha2 as used by JWE (RFC
+// 7518 section 5.2): A128CBC-HS256, A192CBC-HS384 and A256CBC-HS512.
+//
+// The plaintext is PKCS#7 padded and encrypted with the accelerated CBC,
+// then the additional data, IV and ciphertext are authenticated with HMAC
+// (encrypt-then-MAC). Open checks the tag in constant time before it
+// decrypts anything, so a bad padding is never observable.
+package cbchmac
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"sync"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cipher"
+	"github.com/surendarchandra/crypto/internal/alias"
+)
+
+// NonceSize is the size of the nonce, the CBC IV. It must be
+// unpredictable, so generate it randomly for each message.
+const NonceSize = aes.BlockSize
+
+var errOpen = errors.New("cbchmac: message authentication failed")
+
+type cbcHMAC struct {
+	// CBC takes the IV through the block, so encryptions and
+	// decryptions are serialized
+	mu      sync.Mutex
+	block   cipher.Block
+	macKey  []byte
+	hash    func() hash.Hash
+	tagSize int
+}
+
+// NewAES128CBCHMACSHA256 returns A128CBC-HS256 for a 32 byte key: the
+// first half keys HMAC-SHA-256, the second AES-128, and tags are 16 bytes.
+func NewAES128CBCHMACSHA256(key []byte) (cipher.AEAD, error) {
+	return newCBCHMAC(key, 16, sha256.New)
+}
+
+// NewAES192CBCHMACSHA384 returns A192CBC-HS384 for a 48 byte key: the
+// first half keys HMAC-SHA-384, the second AES-192, and tags are 24 bytes.
+func NewAES192CBCHMACSHA384(key []byte) (cipher.AEAD, error) {
+	return newCBCHMAC(key, 24, sha512.New384)
+}
+
+// NewAES256CBCHMACSHA512 returns A256CBC-HS512 for a 64 byte key: the
+// first half keys HMAC-SHA-512, the second AES-256, and tags are 32 bytes.
+func NewAES256CBCHMACSHA512(key []byte) (cipher.AEAD, error) {
+	return newCBCHMAC(key, 32, sha512.New)
+}
+
+// newCBCHMAC splits key into the MAC key and the encryption key, each
+// half bytes, which is also the tag size.
+func newCBCHMAC(key []byte, half int, h func() hash.Hash) (cipher.AEAD, error) {
+	if len(key) != 2*half {
+		return nil, errors.New("cbchmac: invalid key size")
+	}
+
+	block, err := aes.NewCipher(key[half:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &cbcHMAC{
+		block:   block,
+		macKey:  append([]byte(nil), key[:half]...),
+		hash:    h,
+		tagSize: half,
+	}, nil
+}
+
+func (*cbcHMAC) NonceSize() int {
+	return NonceSize
+}
+
+// Overhead is the tag and at most a whole block of padding.
+func (c *cbcHMAC) Overhead() int {
+	return aes.BlockSize + c.tagSize
+}
+
+// tag returns the truncated HMAC of the additional data, IV, ciphertext
+// and the bit length of the additional data (RFC 7518 section 5.2.2.1).
+func (c *cbcHMAC) tag(nonce, ciphertext, additionalData []byte) []byte {
+	var al [8]byte
+	binary.BigEndian.PutUint64(al[:], uint64(len(additionalData))*8)
+
+	m := hmac.New(c.hash, c.macKey)
+	m.Write(additionalData)
+	m.Write(nonce)
+	m.Write(ciphertext)
+	m.Write(al[:])
+
+	return m.Sum(nil)[:c.tagSize]
+}
+
+func (c *cbcHMAC) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != NonceSize {
+		panic("cbchmac: incorrect nonce length given to Seal")
+	}
+
+	padded := cipher.PKCS7Pad(plaintext, aes.BlockSize)
+	ret, out := alias.SliceForAppend(dst, len(padded)+c.tagSize)
+	ciphertext := out[:len(padded)]
+
+	c.mu.Lock()
+	err := cipher.NewCBCEncrypter(c.block, nonce).CryptBlocks(ciphertext, padded)
+	c.mu.Unlock()
+	if err != nil {
+		panic("cbchmac: " + err.Error())
+	}
+
+	copy(out[len(padded):], c.tag(nonce, ciphertext, additionalData))
+
+	return ret
+}
+
+func (c *cbcHMAC) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != NonceSize {
+		panic("cbchmac: incorrect nonce length given to Open")
+	}
+
+	n := len(ciphertext) - c.tagSize
+	if n < aes.BlockSize || n%aes.BlockSize != 0 {
+		return nil, errOpen
+	}
+
+	tag := ciphertext[n:]
+	ciphertext = ciphertext[:n]
+	if !hmac.Equal(c.tag(nonce, ciphertext, additionalData), tag) {
+		return nil, errOpen
+	}
+
+	ret, out := alias.SliceForAppend(dst, n)
+
+	c.mu.Lock()
+	err := cipher.NewCBCDecrypter(c.block, nonce).CryptBlocks(out, ciphertext)
+	c.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the key holder can produce a bad padding under a valid tag
+	plaintext, err := cipher.PKCS7Unpad(out, aes.BlockSize)
+	if err != nil {
+		for i := range out {
+			out[i] = 0
+		}
+		return nil, errOpen
+	}
+
+	return ret[:len(dst)+len(plaintext)], nil
+}
@@ -0,0 +1,153 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cbchmac_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cbchmac"
+	"github.com/surendarchandra/crypto/cipher"
+)
+
+func fromHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// RFC 7518 appendix B: every algorithm seals the same IV, message and
+// additional data
+var (
+	rfcIV        = fromHex("1af38c2dc2b96ffdd86694092341bc04")
+	rfcAD        = fromHex("546865207365636f6e64207072696e6369706c65206f662041756775737465204b6572636b686f666673")
+	rfcPlaintext = fromHex("41206369706865722073797374656d206d757374206e6f7420626520726571756972656420746f206265207365637265742c20616e64206974206d7573742062652061626c6520746f2066616c6c20696e746f207468652068616e6473206f662074686520656e656d7920776974686f757420696e636f6e76656e69656e6365")
+)
+
+var cbcHMACTests = []struct {
+	new                  func([]byte) (cipher.AEAD, error)
+	key, ciphertext, tag string
+}{
+	{
+		cbchmac.NewAES128CBCHMACSHA256,
+		"000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+		"c80edfa32ddf39d5ef00c0b468834279a2e46a1b8049f792f76bfe54b903a9c9a94ac9b47ad2655c5f10f9aef71427e2fc6f9b3f399a221489f16362c703233609d45ac69864e3321cf82935ac4096c86e133314c54019e8ca7980dfa4b9cf1b384c486f3a54c51078158ee5d79de59fbd34d848b3d69550a67646344427ade54b8851ffb598f7f80074b9473c82e2db",
+		"652c3fa36b0a7c5b3219fab3a30bc1c4",
+	},
+	{
+		cbchmac.NewAES192CBCHMACSHA384,
+		"000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f202122232425262728292a2b2c2d2e2f",
+		"ea65da6b59e61edb419be62d19712ae5d303eeb50052d0dfd6697f77224c8edb000d279bdc14c1072654bd30944230c657bed4ca0c9f4a8466f22b226d1746214bf8cfc2400add9f5126e479663fc90b3bed787a2f0ffcbf3904be2a641d5c2105bfe591bae23b1d7449e532eef60a9ac8bb6c6b01d35d49787bcd57ef484927f280adc91ac0c4e79c7b11efc60054e3",
+		"8490ac0e58949bfe51875d733f93ac2075168039ccc733d7",
+	},
+	{
+		cbchmac.NewAES256CBCHMACSHA512,
+		"000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f",
+		"4affaaadb78c31c5da4b1b590d10ffbd3dd8d5d302423526912da037ecbcc7bd822c301dd67c373bccb584ad3e9279c2e6d12a1374b77f077553df829410446b36ebd97066296ae6427ea75c2e0846a11a09ccf5370dc80bfecbad28c73f09b3a3b75e662a2594410ae496b2e2e6609e31e6e02cc837f053d21f37ff4f51950bbe2638d09dd7a4930930806d0703b1f6",
+		"4dd3b4c088a7f45c216839645b2012bf2e6269a8c56a816dbc1b267761955bc5",
+	},
+}
+
+func TestCBCHMAC(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	for i, test := range cbcHMACTests {
+		aead, err := test.new(fromHex(test.key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := append(fromHex(test.ciphertext), fromHex(test.tag)...)
+
+		ct := aead.Seal(nil, rfcIV, rfcPlaintext, rfcAD)
+		if !bytes.Equal(ct, want) {
+			t.Errorf("#%d: got %x, want %x", i, ct, want)
+			continue
+		}
+
+		pt, err := aead.Open(nil, rfcIV, ct, rfcAD)
+		if err != nil {
+			t.Errorf("#%d: Open failed: %v", i, err)
+		} else if !bytes.Equal(pt, rfcPlaintext) {
+			t.Errorf("#%d: got %x, want %x", i, pt, rfcPlaintext)
+		}
+
+		// In place
+		buf := append([]byte(nil), rfcPlaintext...)
+		ct2 := aead.Seal(buf[:0], rfcIV, buf, rfcAD)
+		if !bytes.Equal(ct2, want) {
+			t.Errorf("#%d: in place Seal differs", i)
+		}
+		if pt, err := aead.Open(ct2[:0], rfcIV, ct2, rfcAD); err != nil || !bytes.Equal(pt, rfcPlaintext) {
+			t.Errorf("#%d: in place Open failed: %v", i, err)
+		}
+
+		// Any change to the additional data, IV, ciphertext or tag fails
+		for _, mod := range []struct{ iv, ad, ct []byte }{
+			{rfcIV, rfcAD[1:], want},
+			{append([]byte{1}, rfcIV[1:]...), rfcAD, want},
+			{rfcIV, rfcAD, append(append([]byte(nil), want[:len(want)-1]...), want[len(want)-1]^1)},
+			{rfcIV, rfcAD, append([]byte{want[0] ^ 1}, want[1:]...)},
+			{rfcIV, rfcAD, want[16:]},
+		} {
+			if _, err := aead.Open(nil, mod.iv, mod.ct, mod.ad); err == nil {
+				t.Errorf("#%d: opened a modified message", i)
+			}
+		}
+	}
+}
+
+func TestCBCHMACLengths(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	aead, err := cbchmac.NewAES128CBCHMACSHA256(make([]byte, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce := make([]byte, cbchmac.NonceSize)
+	for n := 0; n <= 48; n++ {
+		msg := bytes.Repeat([]byte{byte(n)}, n)
+		ct := aead.Seal(nil, nonce, msg, nil)
+
+		// Padding always adds 1 to 16 bytes
+		if want := (n/16+1)*16 + 16; len(ct) != want {
+			t.Errorf("%d bytes: sealed to %d, want %d", n, len(ct), want)
+		}
+		if pt, err := aead.Open(nil, nonce, ct, nil); err != nil || !bytes.Equal(pt, msg) {
+			t.Errorf("%d bytes: round trip failed: %v", n, err)
+		}
+	}
+
+	for _, size := range []int{16, 31, 33, 48} {
+		if _, err := cbchmac.NewAES128CBCHMACSHA256(make([]byte, size)); err == nil {
+			t.Errorf("accepted a %d byte key", size)
+		}
+	}
+}
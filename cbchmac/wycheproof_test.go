@@ -0,0 +1,57 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cbchmac_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cbchmac"
+	"github.com/surendarchandra/crypto/cipher"
+	"github.com/surendarchandra/crypto/internal/wycheproof"
+)
+
+func TestWycheproofCBCHMAC(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	for _, test := range []struct {
+		file string
+		new  func([]byte) (cipher.AEAD, error)
+	}{
+		{"a128cbc_hs256_test.json", cbchmac.NewAES128CBCHMACSHA256},
+		{"a192cbc_hs384_test.json", cbchmac.NewAES192CBCHMACSHA384},
+		{"a256cbc_hs512_test.json", cbchmac.NewAES256CBCHMACSHA512},
+	} {
+		t.Run(test.file, func(t *testing.T) {
+			wycheproof.RunAEAD(t, test.file, func(key []byte, nonceSize, tagSize int) (cipher.AEAD, error) {
+				if nonceSize != cbchmac.NonceSize {
+					return nil, errors.New("unsupported nonce size")
+				}
+				return test.new(key)
+			})
+		})
+	}
+}
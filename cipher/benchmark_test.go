@@ -63,6 +63,58 @@ func BenchmarkAESGCMOpen8K(b *testing.B) {
 	benchmarkAESGCMOpen(b, make([]byte, 8*1024))
 }
 
+func benchmarkAESOCBSeal(b *testing.B, buf []byte) {
+	b.SetBytes(int64(len(buf)))
+
+	var key [16]byte
+	var nonce [12]byte
+	var ad [13]byte
+	aes, _ := aes.NewCipher(key[:])
+	aesocb, _ := cipher.NewOCB(aes, 12, 16)
+	var out []byte
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out = aesocb.Seal(out[:0], nonce[:], buf, ad[:])
+	}
+}
+
+func benchmarkAESOCBOpen(b *testing.B, buf []byte) {
+	b.SetBytes(int64(len(buf)))
+
+	var key [16]byte
+	var nonce [12]byte
+	var ad [13]byte
+	aes, _ := aes.NewCipher(key[:])
+	aesocb, _ := cipher.NewOCB(aes, 12, 16)
+	var out []byte
+	out = aesocb.Seal(out[:0], nonce[:], buf, ad[:])
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := aesocb.Open(buf[:0], nonce[:], out, ad[:])
+		if err != nil {
+			b.Errorf("Open: %v", err)
+		}
+	}
+}
+
+func BenchmarkAESOCBSeal1K(b *testing.B) {
+	benchmarkAESOCBSeal(b, make([]byte, 1024))
+}
+
+func BenchmarkAESOCBOpen1K(b *testing.B) {
+	benchmarkAESOCBOpen(b, make([]byte, 1024))
+}
+
+func BenchmarkAESOCBSeal8K(b *testing.B) {
+	benchmarkAESOCBSeal(b, make([]byte, 8*1024))
+}
+
+func BenchmarkAESOCBOpen8K(b *testing.B) {
+	benchmarkAESOCBOpen(b, make([]byte, 8*1024))
+}
+
 func BenchmarkAESCBCEncrypt1K(b *testing.B) {
 	buf := make([]byte, 1024)
 	b.SetBytes(int64(len(buf)))
@@ -83,9 +83,32 @@ func (c *cbc) SetIV(iv []byte) {
 }
 
 func (c *cbc) Encrypt(cipherText, plainText []byte) error {
+	err := c.validate(cipherText, plainText)
+	if err != nil || len(plainText) == 0 {
+		return err
+	}
+
 	return c.block.Encrypt(cipherText, plainText, c.mode)
 }
 
 func (c *cbc) Decrypt(plainText, cipherText []byte) error {
+	err := c.validate(plainText, cipherText)
+	if err != nil || len(cipherText) == 0 {
+		return err
+	}
+
 	return c.block.Decrypt(plainText, cipherText, c.mode)
 }
+
+// ISA-L reads and writes whole blocks without any bounds checks, so
+// partial blocks and short destinations must be rejected here.
+func (c *cbc) validate(dst, src []byte) error {
+	if len(src)%c.block.BlockSize() != 0 {
+		return errors.New("Input not full blocks")
+	}
+	if len(dst) < len(src) {
+		return errors.New("Destination buffer too small")
+	}
+
+	return nil
+}
@@ -0,0 +1,224 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cipher
+
+import (
+	"errors"
+)
+
+// Ciphertext stealing variants of SP 800-38A addendum. All three send the
+// last, possibly partial, block and the truncated second to last block of
+// the CBC encryption of the zero padded input; they differ in the order.
+const (
+	// CBCCS1 keeps the truncated block in place
+	CBCCS1 = iota + 1
+
+	// CBCCS2 swaps the last two blocks unless the input is block
+	// aligned, where it is plain CBC
+	CBCCS2
+
+	// CBCCS3 always swaps the last two blocks, as Kerberos (RFC 3962)
+	CBCCS3
+)
+
+// cbcCS encrypts or decrypts a whole message of at least one block in
+// each call. The IV is held here as each call sets the block's IV more
+// than once.
+type cbcCS struct {
+	block     Block
+	variant   int
+	operation int
+	iv        [16]byte
+}
+
+// NewCBCCSEncrypter creates a AES-CBC encryption system with ciphertext
+// stealing, variant CBCCS1, CBCCS2 or CBCCS3, for inputs of any length of
+// at least one block. The ciphertext is as long as the plaintext.
+func NewCBCCSEncrypter(b Block, iv []byte, variant int) BlockMode {
+	return newCBCCS(b, iv, variant, OperationEncrypt)
+}
+
+// NewCBCCSDecrypter creates a AES-CBC decryption system with ciphertext
+// stealing, variant CBCCS1, CBCCS2 or CBCCS3.
+func NewCBCCSDecrypter(b Block, iv []byte, variant int) BlockMode {
+	return newCBCCS(b, iv, variant, OperationDecrypt)
+}
+
+func newCBCCS(b Block, iv []byte, variant, operation int) BlockMode {
+	if b.BlockSize() != 16 {
+		panic("cipher.NewCBCCS: requires a 128-bit block cipher")
+	}
+	if variant < CBCCS1 || variant > CBCCS3 {
+		panic("cipher.NewCBCCS: unknown ciphertext stealing variant")
+	}
+
+	c := &cbcCS{block: b, variant: variant, operation: operation}
+	c.SetIV(iv)
+
+	return c
+}
+
+func (c *cbcCS) BlockSize() int {
+	return c.block.BlockSize()
+}
+
+func (c *cbcCS) SetIV(iv []byte) {
+	if len(iv) != len(c.iv) {
+		panic("cipher: incorrect length IV")
+	}
+	copy(c.iv[:], iv)
+}
+
+func (c *cbcCS) CryptBlocks(dst, src []byte) error {
+	switch c.operation {
+	case OperationEncrypt:
+		return c.Encrypt(dst, src)
+	case OperationDecrypt:
+		return c.Decrypt(dst, src)
+	}
+
+	return errors.New("Unknown operation")
+}
+
+// swapped reports whether the variant sends the last block before the
+// truncated one, for a last block of d bytes.
+func (c *cbcCS) swapped(d int) bool {
+	return c.variant == CBCCS3 || (c.variant == CBCCS2 && d < 16)
+}
+
+// csSplit returns the number of bytes before the last two blocks and the
+// length of the last one, 1 to 16.
+func csSplit(n int) (int, int) {
+	d := n % 16
+	if d == 0 {
+		d = 16
+	}
+	return n - 16 - d, d
+}
+
+func (c *cbcCS) validate(dst, src []byte) error {
+	if len(src) < 16 {
+		return errors.New("Input shorter than one block")
+	}
+	if len(dst) < len(src) {
+		return errors.New("Destination buffer too small")
+	}
+
+	return nil
+}
+
+func (c *cbcCS) Encrypt(cipherText, plainText []byte) error {
+	if err := c.validate(cipherText, plainText); err != nil {
+		return err
+	}
+	if len(plainText) == 16 {
+		c.block.SetIV(c.iv[:])
+		return c.block.Encrypt(cipherText, plainText, ModeCBC)
+	}
+
+	prefix, d := csSplit(len(plainText))
+
+	// The last two blocks, zero padded, are chained on the prefix
+	var tail [32]byte
+	copy(tail[:], plainText[prefix:])
+
+	chain := c.iv
+	if prefix > 0 {
+		c.block.SetIV(c.iv[:])
+		if err := c.block.Encrypt(cipherText[:prefix], plainText[:prefix], ModeCBC); err != nil {
+			return err
+		}
+		copy(chain[:], cipherText[prefix-16:prefix])
+	}
+	c.block.SetIV(chain[:])
+	if err := c.block.Encrypt(tail[:], tail[:], ModeCBC); err != nil {
+		return err
+	}
+
+	out := cipherText[prefix:len(plainText)]
+	if c.swapped(d) {
+		copy(out, tail[16:])
+		copy(out[16:], tail[:d])
+	} else {
+		copy(out, tail[:d])
+		copy(out[d:], tail[16:])
+	}
+
+	return nil
+}
+
+func (c *cbcCS) Decrypt(plainText, cipherText []byte) error {
+	if err := c.validate(plainText, cipherText); err != nil {
+		return err
+	}
+	if len(cipherText) == 16 {
+		c.block.SetIV(c.iv[:])
+		return c.block.Decrypt(plainText, cipherText, ModeCBC)
+	}
+
+	prefix, d := csSplit(len(cipherText))
+
+	// Gather the truncated block, then the last one, before an in place
+	// decryption of the prefix overwrites the chaining value
+	var tail [32]byte
+	in := cipherText[prefix:]
+	if c.swapped(d) {
+		copy(tail[:d], in[16:])
+		copy(tail[16:], in[:16])
+	} else {
+		copy(tail[:d], in[:d])
+		copy(tail[16:], in[d:])
+	}
+
+	chain := c.iv
+	if prefix > 0 {
+		copy(chain[:], cipherText[prefix-16:prefix])
+		c.block.SetIV(c.iv[:])
+		if err := c.block.Decrypt(plainText[:prefix], cipherText[:prefix], ModeCBC); err != nil {
+			return err
+		}
+	}
+
+	// The last block decrypts to the zero padded last plaintext XORed
+	// with the full second to last ciphertext block, whose missing bytes
+	// it thereby supplies
+	var z [16]byte
+	if err := DecryptBlock(c.block, z[:], tail[16:]); err != nil {
+		return err
+	}
+	copy(tail[d:16], z[d:])
+	for i := 0; i < d; i++ {
+		z[i] ^= tail[i]
+	}
+
+	c.block.SetIV(chain[:])
+	if err := c.block.Decrypt(tail[:16], tail[:16], ModeCBC); err != nil {
+		return err
+	}
+
+	out := plainText[prefix:len(cipherText)]
+	copy(out, tail[:16])
+	copy(out[16:], z[:d])
+
+	return nil
+}
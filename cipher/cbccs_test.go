@@ -0,0 +1,159 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cipher_test
+
+import (
+	"bytes"
+	gaes "crypto/aes"
+	gcipher "crypto/cipher"
+	"encoding/hex"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cipher"
+)
+
+// The RFC 3962 appendix B key and message, with the CS3 results of that
+// appendix; CS1 and CS2 are from OpenSSL's AES-128-CBC-CTS
+var cbcCSKey = []byte("chicken teriyaki")
+
+var cbcCSMessage = []byte("I would like the General Gau's Chicken, please, and wonton soup.")
+
+var cbcCSTests = []struct {
+	variant    int
+	length     int
+	ciphertext string
+}{
+	{cipher.CBCCS1, 17, "97c6353568f2bf8cb4d8a580362da7ff7f"},
+	{cipher.CBCCS1, 31, "97687268d6ecccc0c07b25e25ecfe5fc00783e0efdb2c1d445d4c8eff7ed22"},
+	{cipher.CBCCS1, 32, "97687268d6ecccc0c07b25e25ecfe58439312523a78662d5be7fcbcc98ebf5a8"},
+	{cipher.CBCCS1, 47, "97687268d6ecccc0c07b25e25ecfe58439312523a78662d5be7fcbcc98ebf5b3fffd940c16a18c1b5549d2f838029e"},
+	{cipher.CBCCS1, 48, "97687268d6ecccc0c07b25e25ecfe58439312523a78662d5be7fcbcc98ebf5a89dad8bbb96c4cdc03bc103e1a194bbd8"},
+	{cipher.CBCCS1, 64, "97687268d6ecccc0c07b25e25ecfe58439312523a78662d5be7fcbcc98ebf5a89dad8bbb96c4cdc03bc103e1a194bbd84807efe836ee89a526730dbc2f7bc840"},
+	{cipher.CBCCS2, 17, "c6353568f2bf8cb4d8a580362da7ff7f97"},
+	{cipher.CBCCS2, 31, "fc00783e0efdb2c1d445d4c8eff7ed2297687268d6ecccc0c07b25e25ecfe5"},
+	{cipher.CBCCS2, 32, "97687268d6ecccc0c07b25e25ecfe58439312523a78662d5be7fcbcc98ebf5a8"},
+	{cipher.CBCCS2, 47, "97687268d6ecccc0c07b25e25ecfe584b3fffd940c16a18c1b5549d2f838029e39312523a78662d5be7fcbcc98ebf5"},
+	{cipher.CBCCS2, 48, "97687268d6ecccc0c07b25e25ecfe58439312523a78662d5be7fcbcc98ebf5a89dad8bbb96c4cdc03bc103e1a194bbd8"},
+	{cipher.CBCCS2, 64, "97687268d6ecccc0c07b25e25ecfe58439312523a78662d5be7fcbcc98ebf5a89dad8bbb96c4cdc03bc103e1a194bbd84807efe836ee89a526730dbc2f7bc840"},
+	{cipher.CBCCS3, 17, "c6353568f2bf8cb4d8a580362da7ff7f97"},
+	{cipher.CBCCS3, 31, "fc00783e0efdb2c1d445d4c8eff7ed2297687268d6ecccc0c07b25e25ecfe5"},
+	{cipher.CBCCS3, 32, "39312523a78662d5be7fcbcc98ebf5a897687268d6ecccc0c07b25e25ecfe584"},
+	{cipher.CBCCS3, 47, "97687268d6ecccc0c07b25e25ecfe584b3fffd940c16a18c1b5549d2f838029e39312523a78662d5be7fcbcc98ebf5"},
+	{cipher.CBCCS3, 48, "97687268d6ecccc0c07b25e25ecfe5849dad8bbb96c4cdc03bc103e1a194bbd839312523a78662d5be7fcbcc98ebf5a8"},
+	{cipher.CBCCS3, 64, "97687268d6ecccc0c07b25e25ecfe58439312523a78662d5be7fcbcc98ebf5a84807efe836ee89a526730dbc2f7bc8409dad8bbb96c4cdc03bc103e1a194bbd8"},
+}
+
+func TestCBCCSAES(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	block, err := aes.NewCipher(cbcCSKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	iv := make([]byte, aes.BlockSize)
+
+	for _, test := range cbcCSTests {
+		plaintext := cbcCSMessage[:test.length]
+		ciphertext, _ := hex.DecodeString(test.ciphertext)
+
+		data := make([]byte, len(plaintext))
+		if err := cipher.NewCBCCSEncrypter(block, iv, test.variant).CryptBlocks(data, plaintext); err != nil {
+			t.Fatalf("CS%d %d: %v", test.variant, test.length, err)
+		}
+		if !bytes.Equal(data, ciphertext) {
+			t.Errorf("CS%d %d: encrypt\nhave %x\nwant %x", test.variant, test.length, data, ciphertext)
+		}
+
+		// In place
+		if err := cipher.NewCBCCSDecrypter(block, iv, test.variant).CryptBlocks(data, data); err != nil {
+			t.Fatalf("CS%d %d: %v", test.variant, test.length, err)
+		}
+		if !bytes.Equal(data, plaintext) {
+			t.Errorf("CS%d %d: decrypt\nhave %x\nwant %x", test.variant, test.length, data, plaintext)
+		}
+	}
+}
+
+func TestCBCCSAESRoundTrip(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	msg := bytes.Repeat([]byte("ciphertext stealing "), 10)
+	for _, key := range [][]byte{commonKey128, commonKey192, commonKey256} {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gblock, _ := gaes.NewCipher(key)
+
+		for n := 16; n <= len(msg); n++ {
+			ct := make([]byte, n)
+			for v := cipher.CBCCS1; v <= cipher.CBCCS3; v++ {
+				enc := cipher.NewCBCCSEncrypter(block, commonIV, v)
+				if err := enc.CryptBlocks(ct, msg[:n]); err != nil {
+					t.Fatal(err)
+				}
+
+				// Block aligned CS1 and CS2 are plain CBC
+				if n%16 == 0 && v != cipher.CBCCS3 {
+					want := make([]byte, n)
+					gcipher.NewCBCEncrypter(gblock, commonIV).CryptBlocks(want, msg[:n])
+					if !bytes.Equal(ct, want) {
+						t.Errorf("key %d, CS%d, %d bytes: differs from CBC", len(key), v, n)
+					}
+				}
+
+				pt := make([]byte, n)
+				dec := cipher.NewCBCCSDecrypter(block, commonIV, v)
+				if err := dec.CryptBlocks(pt, ct); err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(pt, msg[:n]) {
+					t.Errorf("key %d, CS%d, %d bytes: round trip failed", len(key), v, n)
+				}
+			}
+		}
+	}
+}
+
+func TestCBCCSAESInvalid(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	block, err := aes.NewCipher(commonKey128)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 32)
+	if err := cipher.NewCBCCSEncrypter(block, commonIV, cipher.CBCCS3).CryptBlocks(buf, buf[:15]); err == nil {
+		t.Error("accepted less than a block")
+	}
+	if err := cipher.NewCBCCSDecrypter(block, commonIV, cipher.CBCCS1).CryptBlocks(buf[:20], buf[:21]); err == nil {
+		t.Error("accepted a short destination")
+	}
+}
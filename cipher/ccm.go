@@ -0,0 +1,229 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cipher
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+
+	"github.com/surendarchandra/crypto/internal/alias"
+	"github.com/surendarchandra/crypto/internal/ctrmode"
+)
+
+// ccm represents Counter with CBC-MAC mode, SP 800-38C and RFC 3610.
+type ccm struct {
+	block     Block
+	nonceSize int
+	tagSize   int
+}
+
+// NewCCM returns the given 128-bit block cipher wrapped in CCM with the
+// given nonce and tag sizes. The nonce may be 7 to 13 bytes; it leaves
+// 15 - nonceSize bytes to encode the message length, so shorter nonces
+// allow longer messages. The tag may be 4, 6, 8, 10, 12, 14 or 16 bytes.
+func NewCCM(block Block, nonceSize, tagSize int) (AEAD, error) {
+	if block.BlockSize() != ccmBlockSize {
+		return nil, errors.New("cipher: CCM requires a 128-bit block cipher")
+	}
+	if nonceSize < 7 || nonceSize > 13 {
+		return nil, errors.New("cipher: CCM nonce size must be between 7 and 13 bytes")
+	}
+	if tagSize < 4 || tagSize > 16 || tagSize%2 != 0 {
+		return nil, errors.New("cipher: CCM tag size must be an even number between 4 and 16 bytes")
+	}
+
+	// CTR needs the ECB mode and CBC-MAC the CBC mode, which XTS keys
+	// lack; probing ECB tells them apart
+	var probe [ccmBlockSize]byte
+	if err := EncryptBlock(block, probe[:], probe[:]); err != nil {
+		return nil, errors.New("cipher: CCM not supported for this key size")
+	}
+
+	return &ccm{block: block, nonceSize: nonceSize, tagSize: tagSize}, nil
+}
+
+const ccmBlockSize = 16
+
+func (c *ccm) NonceSize() int {
+	return c.nonceSize
+}
+
+func (c *ccm) Overhead() int {
+	return c.tagSize
+}
+
+// maxLength is the longest message whose length fits in 15 - nonceSize
+// bytes.
+func (c *ccm) maxLength() uint64 {
+	q := uint(15 - c.nonceSize)
+	if q >= 8 {
+		return 1<<63 - 1
+	}
+	return 1<<(8*q) - 1
+}
+
+// cbcMACSum returns the last block of the CBC encryption of data, a whole
+// number of blocks, with a zero IV. Long inputs go through in chunks,
+// chaining on the last block.
+func cbcMACSum(b Block, data []byte) ([16]byte, error) {
+	var iv [16]byte
+	var scratch [4096]byte
+
+	for len(data) > 0 {
+		n := len(data)
+		if n > len(scratch) {
+			n = len(scratch)
+		}
+		b.SetIV(iv[:])
+		if err := b.Encrypt(scratch[:n], data[:n], ModeCBC); err != nil {
+			return iv, err
+		}
+		copy(iv[:], scratch[n-len(iv):n])
+		data = data[n:]
+	}
+
+	return iv, nil
+}
+
+// mac computes the unencrypted tag (SP 800-38C section A.2): CBC-MAC over
+// B0, the encoded additional data and the message, each zero padded.
+func (c *ccm) mac(nonce, plaintext, additionalData []byte) ([16]byte, error) {
+	q := 15 - c.nonceSize
+
+	n := ccmBlockSize
+	if len(additionalData) > 0 {
+		n += (10 + len(additionalData) + ccmBlockSize - 1) / ccmBlockSize * ccmBlockSize
+	}
+	n += (len(plaintext) + ccmBlockSize - 1) / ccmBlockSize * ccmBlockSize
+	buf := make([]byte, n)
+
+	// B0: flags, nonce and message length
+	buf[0] = byte((c.tagSize-2)/2<<3 | (q - 1))
+	if len(additionalData) > 0 {
+		buf[0] |= 0x40
+	}
+	copy(buf[1:], nonce)
+	var length [8]byte
+	binary.BigEndian.PutUint64(length[:], uint64(len(plaintext)))
+	copy(buf[1+c.nonceSize:16], length[8-q:])
+
+	off := ccmBlockSize
+	if a := uint64(len(additionalData)); a > 0 {
+		switch {
+		case a < 1<<16-1<<8:
+			binary.BigEndian.PutUint16(buf[off:], uint16(a))
+			off += 2
+		case a < 1<<32:
+			buf[off], buf[off+1] = 0xff, 0xfe
+			binary.BigEndian.PutUint32(buf[off+2:], uint32(a))
+			off += 6
+		default:
+			buf[off], buf[off+1] = 0xff, 0xff
+			binary.BigEndian.PutUint64(buf[off+2:], a)
+			off += 10
+		}
+		off += copy(buf[off:], additionalData)
+		off = (off + ccmBlockSize - 1) / ccmBlockSize * ccmBlockSize
+	}
+	copy(buf[off:], plaintext)
+
+	return cbcMACSum(c.block, buf[:off+(len(plaintext)+ccmBlockSize-1)/ccmBlockSize*ccmBlockSize])
+}
+
+// ctr XORs src with the keystream of counter blocks 1, 2, ... into dst
+// and returns the encryption of counter block 0, which masks the tag.
+// The message length limit keeps the counter from overflowing its 15 -
+// nonceSize bytes into the nonce.
+func (c *ccm) ctr(dst, src, nonce []byte) ([16]byte, error) {
+	var counter, s0 [16]byte
+	counter[0] = byte(14 - c.nonceSize)
+	copy(counter[1:], nonce)
+	if err := EncryptBlock(c.block, s0[:], counter[:]); err != nil {
+		return s0, err
+	}
+
+	encrypt := func(dst, src []byte) error { return EncryptBlocks(c.block, dst, src) }
+	ctrmode.IncrementBE(counter[:])
+	return s0, ctrmode.XORKeyStream(encrypt, dst, src, counter[:], ctrmode.IncrementBE)
+}
+
+func (c *ccm) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != c.nonceSize {
+		panic("cipher: incorrect nonce length given to CCM")
+	}
+	if uint64(len(plaintext)) > c.maxLength() {
+		panic("cipher: message too large for CCM")
+	}
+
+	tag, err := c.mac(nonce, plaintext, additionalData)
+	if err != nil {
+		panic("cipher: CCM: " + err.Error())
+	}
+
+	ret, out := alias.SliceForAppend(dst, len(plaintext)+c.tagSize)
+	s0, err := c.ctr(out, plaintext, nonce)
+	if err != nil {
+		panic("cipher: CCM: " + err.Error())
+	}
+	for i := 0; i < c.tagSize; i++ {
+		out[len(plaintext)+i] = tag[i] ^ s0[i]
+	}
+
+	return ret
+}
+
+func (c *ccm) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != c.nonceSize {
+		panic("cipher: incorrect nonce length given to CCM")
+	}
+	if len(ciphertext) < c.tagSize || uint64(len(ciphertext)-c.tagSize) > c.maxLength() {
+		return nil, errOpen
+	}
+
+	var expected [16]byte
+	copy(expected[:], ciphertext[len(ciphertext)-c.tagSize:])
+	ciphertext = ciphertext[:len(ciphertext)-c.tagSize]
+
+	ret, out := alias.SliceForAppend(dst, len(ciphertext))
+	s0, err := c.ctr(out, ciphertext, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := c.mac(nonce, out, additionalData)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < c.tagSize; i++ {
+		tag[i] ^= s0[i]
+	}
+	if subtle.ConstantTimeCompare(tag[:c.tagSize], expected[:c.tagSize]) != 1 {
+		for i := range out {
+			out[i] = 0
+		}
+		return nil, errOpen
+	}
+
+	return ret, nil
+}
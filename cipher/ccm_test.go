@@ -0,0 +1,170 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cipher_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cipher"
+)
+
+// SP 800-38C appendix C examples 1 to 4
+var aesCCMTests = []struct {
+	key, nonce, plaintext, ad, result string
+	tagSize                           int
+}{
+	{
+		"404142434445464748494a4b4c4d4e4f",
+		"10111213141516",
+		"20212223",
+		"0001020304050607",
+		"7162015b4dac255d",
+		4,
+	},
+	{
+		"404142434445464748494a4b4c4d4e4f",
+		"1011121314151617",
+		"202122232425262728292a2b2c2d2e2f",
+		"000102030405060708090a0b0c0d0e0f",
+		"d2a1f0e051ea5f62081a7792073d593d1fc64fbfaccd",
+		6,
+	},
+	{
+		"404142434445464748494a4b4c4d4e4f",
+		"101112131415161718191a1b",
+		"202122232425262728292a2b2c2d2e2f3031323334353637",
+		"000102030405060708090a0b0c0d0e0f10111213",
+		"e3b201a9f5b71a7a9b1ceaeccd97e70b6176aad9a4428aa5484392fbc1b09951",
+		8,
+	},
+}
+
+func TestAESCCM(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	// Example 4 has 2^16 bytes of additional data, 0x00 to 0xff repeated
+	var ad4 bytes.Buffer
+	for i := 0; i < 1<<16; i++ {
+		ad4.WriteByte(byte(i))
+	}
+	tests := append(aesCCMTests, struct {
+		key, nonce, plaintext, ad, result string
+		tagSize                           int
+	}{
+		"404142434445464748494a4b4c4d4e4f",
+		"101112131415161718191a1b1c",
+		"202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f",
+		hex.EncodeToString(ad4.Bytes()),
+		"69915dad1e84c6376a68c2967e4dab615ae0fd1faec44cc484828529463ccf72b4ac6bec93e8598e7f0dadbcea5b",
+		14,
+	})
+
+	for i, test := range tests {
+		key, _ := hex.DecodeString(test.key)
+		nonce, _ := hex.DecodeString(test.nonce)
+		plaintext, _ := hex.DecodeString(test.plaintext)
+		ad, _ := hex.DecodeString(test.ad)
+
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		aead, err := cipher.NewCCM(block, len(nonce), test.tagSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ct := aead.Seal(nil, nonce, plaintext, ad)
+		if got := hex.EncodeToString(ct); got != test.result {
+			t.Errorf("#%d: got %s, want %s", i, got, test.result)
+			continue
+		}
+
+		pt, err := aead.Open(nil, nonce, ct, ad)
+		if err != nil {
+			t.Errorf("#%d: Open failed: %v", i, err)
+		} else if !bytes.Equal(pt, plaintext) {
+			t.Errorf("#%d: got %x, want %x", i, pt, plaintext)
+		}
+
+		// In place
+		buf := append([]byte(nil), plaintext...)
+		if ct2 := aead.Seal(buf[:0], nonce, buf, ad); !bytes.Equal(ct2, ct) {
+			t.Errorf("#%d: in place Seal differs", i)
+		}
+
+		ct[len(ct)-1] ^= 1
+		if _, err := aead.Open(nil, nonce, ct, ad); err == nil {
+			t.Errorf("#%d: opened a modified tag", i)
+		}
+	}
+}
+
+func TestAESCCMParameters(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	block, err := aes.NewCipher(make([]byte, 16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range [][2]int{{6, 16}, {14, 16}, {13, 3}, {13, 5}, {13, 18}} {
+		if _, err := cipher.NewCCM(block, p[0], p[1]); err == nil {
+			t.Errorf("accepted nonce size %d and tag size %d", p[0], p[1])
+		}
+	}
+
+	// Nonce sizes 7 to 13 with every tag size must round trip
+	msg := bytes.Repeat([]byte("ccm"), 30)
+	for n := 7; n <= 13; n++ {
+		for tag := 4; tag <= 16; tag += 2 {
+			aead, err := cipher.NewCCM(block, n, tag)
+			if err != nil {
+				t.Fatal(err)
+			}
+			nonce := make([]byte, n)
+			ct := aead.Seal(nil, nonce, msg, []byte("ad"))
+			if len(ct) != len(msg)+tag {
+				t.Errorf("nonce %d tag %d: output length %d", n, tag, len(ct))
+			}
+			if pt, err := aead.Open(nil, nonce, ct, []byte("ad")); err != nil || !bytes.Equal(pt, msg) {
+				t.Errorf("nonce %d tag %d: round trip failed: %v", n, tag, err)
+			}
+		}
+	}
+
+	// XTS keys have no CBC mode
+	xts, err := aes.NewCipher(make([]byte, 64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cipher.NewCCM(xts, 12, 16); err == nil {
+		t.Error("accepted an XTS-256 key")
+	}
+}
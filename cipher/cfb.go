@@ -0,0 +1,197 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cipher
+
+import (
+	"errors"
+)
+
+// cfbChunkSize bounds the blocks built for a bulk decryption
+const cfbChunkSize = 4096
+
+// CFB (SP 800-38A section 6.3) feeds back segments of ciphertext into the
+// block input. Encryption is inherently serial, one block encryption per
+// segment. Decryption knows every block input up front, so whole runs of
+// segments go through ECB in a single call.
+type cfb struct {
+	block   Block
+	decrypt bool
+
+	// register is the next block input, out the key stream for it and
+	// used the number of bytes of out consumed
+	register [16]byte
+	out      [16]byte
+	used     int
+
+	segment int // bytes
+
+	in, ks [cfbChunkSize + 16]byte
+}
+
+// NewCFBEncrypter returns a Stream which encrypts with AES-CFB using iv
+// as the first block input. segmentSize is 8 (CFB8, RFC 3826) or 128
+// (CFB128, as crypto/cipher) bits.
+func NewCFBEncrypter(b Block, iv []byte, segmentSize int) (Stream, error) {
+	return newCFB(b, iv, segmentSize, false)
+}
+
+// NewCFBDecrypter returns a Stream which decrypts with AES-CFB using iv
+// as the first block input. segmentSize is 8 (CFB8, RFC 3826) or 128
+// (CFB128, as crypto/cipher) bits.
+func NewCFBDecrypter(b Block, iv []byte, segmentSize int) (Stream, error) {
+	return newCFB(b, iv, segmentSize, true)
+}
+
+func newCFB(b Block, iv []byte, segmentSize int, decrypt bool) (Stream, error) {
+	if b.BlockSize() != 16 {
+		return nil, errors.New("cipher: CFB requires a 128-bit block cipher")
+	}
+	if len(iv) != b.BlockSize() {
+		return nil, errors.New("cipher: CFB IV length must equal block size")
+	}
+	if segmentSize != 8 && segmentSize != 128 {
+		return nil, errors.New("cipher: CFB segment size must be 8 or 128 bits")
+	}
+
+	c := &cfb{block: b, decrypt: decrypt, segment: segmentSize / 8}
+	copy(c.register[:], iv)
+
+	// Both directions of CFB run only the forward cipher, through the
+	// ECB mode. XTS keys have none, so probe it here: XORKeyStream
+	// cannot return an error and panics on one
+	if err := EncryptBlock(b, c.out[:], c.out[:]); err != nil {
+		return nil, errors.New("cipher: CFB not supported for this key size")
+	}
+	c.used = 16
+
+	return c, nil
+}
+
+func (c *cfb) XORKeyStream(dst, src []byte) {
+	if len(dst) < len(src) {
+		panic("cipher: output smaller than input")
+	}
+
+	if c.segment == 1 {
+		c.xor8(dst, src)
+	} else {
+		c.xor128(dst, src)
+	}
+}
+
+// xor128 is CFB128. A partial block of key stream carries over to the
+// next call, with register filled in as the ciphertext bytes appear.
+func (c *cfb) xor128(dst, src []byte) {
+	for len(src) > 0 {
+		if c.used == 16 && c.decrypt && len(src) >= 16 {
+			n := c.decryptBlocks(dst, src)
+			dst, src = dst[n:], src[n:]
+			continue
+		}
+		if c.used == 16 {
+			if err := EncryptBlock(c.block, c.out[:], c.register[:]); err != nil {
+				panic("cipher: CFB: " + err.Error())
+			}
+			c.used = 0
+		}
+
+		n := 16 - c.used
+		if n > len(src) {
+			n = len(src)
+		}
+		for i := 0; i < n; i++ {
+			s := src[i]
+			dst[i] = s ^ c.out[c.used+i]
+			if c.decrypt {
+				c.register[c.used+i] = s
+			} else {
+				c.register[c.used+i] = dst[i]
+			}
+		}
+		c.used += n
+		dst, src = dst[n:], src[n:]
+	}
+}
+
+// decryptBlocks decrypts the whole blocks at the start of src, up to a
+// chunk, in a single ECB call: the block inputs are register followed by
+// all but the last ciphertext block. It returns the number of bytes done.
+func (c *cfb) decryptBlocks(dst, src []byte) int {
+	n := len(src) / 16 * 16
+	if n > cfbChunkSize {
+		n = cfbChunkSize
+	}
+
+	copy(c.in[:16], c.register[:])
+	copy(c.in[16:], src[:n])
+	if err := EncryptBlocks(c.block, c.ks[:n], c.in[:n]); err != nil {
+		panic("cipher: CFB: " + err.Error())
+	}
+	for i := 0; i < n; i++ {
+		dst[i] = c.in[16+i] ^ c.ks[i]
+	}
+	copy(c.register[:], c.in[n:n+16])
+
+	return n
+}
+
+// xor8 is CFB8: each byte takes a block encryption of the register, which
+// then shifts in the ciphertext byte. Decryption encrypts every register
+// state of a chunk, a 16 byte window sliding over the ciphertext, at once.
+func (c *cfb) xor8(dst, src []byte) {
+	if !c.decrypt {
+		for i, s := range src {
+			if err := EncryptBlock(c.block, c.out[:], c.register[:]); err != nil {
+				panic("cipher: CFB: " + err.Error())
+			}
+			dst[i] = s ^ c.out[0]
+			copy(c.register[:], c.register[1:])
+			c.register[15] = dst[i]
+		}
+		return
+	}
+
+	for len(src) > 0 {
+		n := len(src)
+		if n > cfbChunkSize/16 {
+			n = cfbChunkSize / 16
+		}
+
+		// window is the register followed by the ciphertext
+		window := c.ks[cfbChunkSize-n-16 : cfbChunkSize]
+		copy(window, c.register[:])
+		copy(window[16:], src[:n])
+		for i := 0; i < n; i++ {
+			copy(c.in[16*i:], window[i:i+16])
+		}
+		copy(c.register[:], window[n:])
+
+		if err := EncryptBlocks(c.block, c.in[:16*n], c.in[:16*n]); err != nil {
+			panic("cipher: CFB: " + err.Error())
+		}
+		for i := 0; i < n; i++ {
+			dst[i] = window[16+i] ^ c.in[16*i]
+		}
+		dst, src = dst[n:], src[n:]
+	}
+}
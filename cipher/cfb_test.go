@@ -0,0 +1,187 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cipher_test
+
+import (
+	"bytes"
+	gaes "crypto/aes"
+	gcipher "crypto/cipher"
+	"encoding/hex"
+	"math/rand"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cipher"
+)
+
+// SP 800-38A appendix F.3.7, F.3.9 and F.3.11 CFB8 examples
+var cfb8AESTests = []struct {
+	name       string
+	key        []byte
+	ciphertext string
+}{
+	{"CFB8-AES128", commonKey128, "3b79424c9c0dd436bace9e0ed4586a4f32b9"},
+	{"CFB8-AES192", commonKey192, "cda2521ef0a905ca44cd057cbf0d47a0678a"},
+	{"CFB8-AES256", commonKey256, "dc1f1a8520a64db55fcc8ac554844e889700"},
+}
+
+func TestCFB8AES(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	plaintext := commonInput[:18]
+	for _, test := range cfb8AESTests {
+		ciphertext, _ := hex.DecodeString(test.ciphertext)
+
+		block, err := aes.NewCipher(test.key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		enc, err := cipher.NewCFBEncrypter(block, commonIV, 8)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data := make([]byte, len(plaintext))
+		enc.XORKeyStream(data, plaintext)
+		if !bytes.Equal(data, ciphertext) {
+			t.Errorf("%s: encrypt\nhave %x\nwant %x", test.name, data, ciphertext)
+		}
+
+		dec, err := cipher.NewCFBDecrypter(block, commonIV, 8)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dec.XORKeyStream(data, data)
+		if !bytes.Equal(data, plaintext) {
+			t.Errorf("%s: decrypt\nhave %x\nwant %x", test.name, data, plaintext)
+		}
+	}
+}
+
+// cfb8Reference is CFB8 straight from the definition, one block
+// encryption per byte.
+func cfb8Reference(block gcipher.Block, iv, dst, src []byte, decrypt bool) {
+	var register, out [16]byte
+	copy(register[:], iv)
+	for i, s := range src {
+		block.Encrypt(out[:], register[:])
+		dst[i] = s ^ out[0]
+		copy(register[:], register[1:])
+		if decrypt {
+			register[15] = s
+		} else {
+			register[15] = dst[i]
+		}
+	}
+}
+
+// xorInPieces runs src through s in place, split at random points, so
+// partial blocks carry over between calls.
+func xorInPieces(r *rand.Rand, s cipher.Stream, src []byte) []byte {
+	out := append([]byte(nil), src...)
+	for data := out; len(data) > 0; {
+		n := r.Intn(len(data)) + 1
+		if r.Intn(4) == 0 {
+			n = r.Intn(20) + 1
+			if n > len(data) {
+				n = len(data)
+			}
+		}
+		s.XORKeyStream(data[:n], data[:n])
+		data = data[n:]
+	}
+	return out
+}
+
+func TestCFBAESAgainstGo(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for _, key := range [][]byte{commonKey128, commonKey192, commonKey256} {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gblock, _ := gaes.NewCipher(key)
+
+		for _, n := range []int{0, 1, 15, 16, 17, 100, 4095, 4096, 4097, 10000} {
+			msg := make([]byte, n)
+			r.Read(msg)
+
+			want := make([]byte, n)
+			gcipher.NewCFBEncrypter(gblock, commonIV).XORKeyStream(want, msg)
+			enc, _ := cipher.NewCFBEncrypter(block, commonIV, 128)
+			if got := xorInPieces(r, enc, msg); !bytes.Equal(got, want) {
+				t.Errorf("CFB128 key %d, %d bytes: encryption differs from crypto/cipher", len(key), n)
+			}
+			dec, _ := cipher.NewCFBDecrypter(block, commonIV, 128)
+			if got := xorInPieces(r, dec, want); !bytes.Equal(got, msg) {
+				t.Errorf("CFB128 key %d, %d bytes: decryption failed", len(key), n)
+			}
+
+			cfb8Reference(gblock, commonIV, want, msg, false)
+			enc, _ = cipher.NewCFBEncrypter(block, commonIV, 8)
+			if got := xorInPieces(r, enc, msg); !bytes.Equal(got, want) {
+				t.Errorf("CFB8 key %d, %d bytes: encryption differs from the reference", len(key), n)
+			}
+			dec, _ = cipher.NewCFBDecrypter(block, commonIV, 8)
+			if got := xorInPieces(r, dec, want); !bytes.Equal(got, msg) {
+				t.Errorf("CFB8 key %d, %d bytes: decryption failed", len(key), n)
+			}
+		}
+	}
+}
+
+func TestCFBParameters(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	block, err := aes.NewCipher(commonKey128)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []int{0, 1, 64, 256} {
+		if _, err := cipher.NewCFBEncrypter(block, commonIV, s); err == nil {
+			t.Errorf("accepted segment size %d", s)
+		}
+	}
+	for _, n := range []int{0, 8, 17} {
+		if _, err := cipher.NewCFBEncrypter(block, make([]byte, n), 128); err == nil {
+			t.Errorf("accepted a %d byte IV", n)
+		}
+	}
+
+	// XTS keys have no ECB mode
+	xts, err := aes.NewCipher(make([]byte, 64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cipher.NewCFBDecrypter(xts, commonIV, 128); err == nil {
+		t.Error("accepted an XTS key")
+	}
+}
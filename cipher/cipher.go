@@ -48,6 +48,11 @@ const (
 
 	// ModeCBC is the AES-CBC mode
 	ModeCBC
+
+	// ModeECB is raw AES applied to each block independently. It is a
+	// building block for other modes and must not be used to encrypt
+	// data directly.
+	ModeECB
 )
 
 const (
@@ -70,3 +75,10 @@ type BlockMode interface {
 
 	CryptBlocks(dst, src []byte) error
 }
+
+// A Stream represents a stream cipher (CFB, OFB etc).
+type Stream interface {
+	// XORKeyStream XORs each byte in src with a byte from the cipher's key
+	// stream into dst. dst and src must overlap entirely or not at all.
+	XORKeyStream(dst, src []byte)
+}
@@ -0,0 +1,178 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cipher
+
+import (
+	"crypto/subtle"
+	"errors"
+
+	"github.com/surendarchandra/crypto/internal/alias"
+	"github.com/surendarchandra/crypto/internal/ctrmode"
+	"github.com/surendarchandra/crypto/internal/gf128"
+)
+
+// eax represents the EAX mode of Bellare, Rogaway and Wagner: CTR
+// encryption authenticated with OMAC (CMAC).
+type eax struct {
+	block     Block
+	nonceSize int
+	tagSize   int
+
+	// k1 and k2 are the CMAC subkeys for full and padded last blocks
+	k1, k2 [eaxBlockSize]byte
+}
+
+// NewEAX returns the given 128-bit block cipher wrapped in EAX with the
+// given nonce and tag sizes. EAX hashes the nonce, so it may be any
+// length, including empty. The tag may be 1 to 16 bytes.
+func NewEAX(block Block, nonceSize, tagSize int) (AEAD, error) {
+	if block.BlockSize() != eaxBlockSize {
+		return nil, errors.New("cipher: EAX requires a 128-bit block cipher")
+	}
+	if nonceSize < 0 {
+		return nil, errors.New("cipher: EAX nonce size must not be negative")
+	}
+	if tagSize < 1 || tagSize > 16 {
+		return nil, errors.New("cipher: EAX tag size must be between 1 and 16 bytes")
+	}
+
+	e := &eax{block: block, nonceSize: nonceSize, tagSize: tagSize}
+
+	// OMAC needs the CBC mode and CTR the ECB mode, which XTS keys lack;
+	// deriving the subkeys through ECB rejects them
+	var l [eaxBlockSize]byte
+	if err := EncryptBlock(block, l[:], l[:]); err != nil {
+		return nil, errors.New("cipher: EAX not supported for this key size")
+	}
+	e.k1 = gf128.Double(l)
+	e.k2 = gf128.Double(e.k1)
+
+	return e, nil
+}
+
+const eaxBlockSize = 16
+
+func (e *eax) NonceSize() int {
+	return e.nonceSize
+}
+
+func (e *eax) Overhead() int {
+	return e.tagSize
+}
+
+// omac returns OMAC^t(data), the CMAC of the block [t]_16 followed by
+// data.
+func (e *eax) omac(t byte, data []byte) ([eaxBlockSize]byte, error) {
+	n := eaxBlockSize + len(data)
+	buf := make([]byte, (n+eaxBlockSize-1)/eaxBlockSize*eaxBlockSize)
+	buf[eaxBlockSize-1] = t
+	copy(buf[eaxBlockSize:], data)
+
+	last := buf[len(buf)-eaxBlockSize:]
+	if n%eaxBlockSize == 0 {
+		xorBlock(last, last, e.k1[:])
+	} else {
+		buf[n] = 0x80
+		xorBlock(last, last, e.k2[:])
+	}
+
+	return cbcMACSum(e.block, buf)
+}
+
+// ctr XORs src with the keystream that starts at counter block iv into
+// dst. The counter is the whole block, incremented as a big-endian
+// integer.
+func (e *eax) ctr(dst, src []byte, iv [eaxBlockSize]byte) error {
+	encrypt := func(dst, src []byte) error { return EncryptBlocks(e.block, dst, src) }
+	return ctrmode.XORKeyStream(encrypt, dst, src, iv[:], ctrmode.IncrementBE)
+}
+
+func (e *eax) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != e.nonceSize {
+		panic("cipher: incorrect nonce length given to EAX")
+	}
+
+	ret, out := alias.SliceForAppend(dst, len(plaintext)+e.tagSize)
+
+	n, err := e.omac(0, nonce)
+	if err != nil {
+		panic("cipher: EAX: " + err.Error())
+	}
+	h, err := e.omac(1, additionalData)
+	if err != nil {
+		panic("cipher: EAX: " + err.Error())
+	}
+	if err := e.ctr(out, plaintext, n); err != nil {
+		panic("cipher: EAX: " + err.Error())
+	}
+	c, err := e.omac(2, out[:len(plaintext)])
+	if err != nil {
+		panic("cipher: EAX: " + err.Error())
+	}
+
+	for i := 0; i < e.tagSize; i++ {
+		out[len(plaintext)+i] = n[i] ^ h[i] ^ c[i]
+	}
+
+	return ret
+}
+
+func (e *eax) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != e.nonceSize {
+		panic("cipher: incorrect nonce length given to EAX")
+	}
+	if len(ciphertext) < e.tagSize {
+		return nil, errOpen
+	}
+
+	expected := ciphertext[len(ciphertext)-e.tagSize:]
+	ciphertext = ciphertext[:len(ciphertext)-e.tagSize]
+
+	// The tag covers the ciphertext, so it is checked before decrypting
+	n, err := e.omac(0, nonce)
+	if err != nil {
+		return nil, err
+	}
+	h, err := e.omac(1, additionalData)
+	if err != nil {
+		return nil, err
+	}
+	c, err := e.omac(2, ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	var tag [eaxBlockSize]byte
+	for i := range tag {
+		tag[i] = n[i] ^ h[i] ^ c[i]
+	}
+	if subtle.ConstantTimeCompare(tag[:e.tagSize], expected) != 1 {
+		return nil, errOpen
+	}
+
+	ret, out := alias.SliceForAppend(dst, len(ciphertext))
+	if err := e.ctr(out, ciphertext, n); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
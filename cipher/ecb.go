@@ -0,0 +1,78 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cipher
+
+import (
+	"errors"
+)
+
+// ECB encrypts each block on its own, so equal plaintext blocks give equal
+// ciphertext blocks. It is exposed as a building block for other modes,
+// key wrapping, MACs and test vectors, and must not be used to encrypt
+// data directly.
+
+// NewECBEncrypter creates a AES-ECB encryption system. It is meant for
+// building other constructions and for test vectors only.
+func NewECBEncrypter(b Block) BlockMode {
+	return &cbc{block: b, mode: ModeECB, operation: OperationEncrypt}
+}
+
+// NewECBDecrypter creates a AES-ECB decryption system. It is meant for
+// building other constructions and for test vectors only.
+func NewECBDecrypter(b Block) BlockMode {
+	return &cbc{block: b, mode: ModeECB, operation: OperationDecrypt}
+}
+
+// EncryptBlock encrypts the single block src into dst, which may be the
+// same slice. It fails for keys without ECB, such as XTS keys.
+func EncryptBlock(b Block, dst, src []byte) error {
+	if len(src) != b.BlockSize() || len(dst) < len(src) {
+		return errors.New("cipher: EncryptBlock needs exactly one block")
+	}
+
+	return b.Encrypt(dst, src, ModeECB)
+}
+
+// DecryptBlock decrypts the single block src into dst, which may be the
+// same slice. It fails for keys without ECB, such as XTS keys.
+func DecryptBlock(b Block, dst, src []byte) error {
+	if len(src) != b.BlockSize() || len(dst) < len(src) {
+		return errors.New("cipher: DecryptBlock needs exactly one block")
+	}
+
+	return b.Decrypt(dst, src, ModeECB)
+}
+
+// EncryptBlocks encrypts each block of src, a whole number of blocks,
+// independently into dst in a single call. dst and src must overlap
+// entirely or not at all.
+func EncryptBlocks(b Block, dst, src []byte) error {
+	return NewECBEncrypter(b).CryptBlocks(dst, src)
+}
+
+// DecryptBlocks decrypts each block of src, a whole number of blocks,
+// independently into dst in a single call. dst and src must overlap
+// entirely or not at all.
+func DecryptBlocks(b Block, dst, src []byte) error {
+	return NewECBDecrypter(b).CryptBlocks(dst, src)
+}
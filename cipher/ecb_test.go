@@ -0,0 +1,152 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cipher_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cipher"
+)
+
+// SP 800-38A appendix F.1 ECB examples
+var ecbAESTests = []struct {
+	name, key, plaintext, ciphertext string
+}{
+	{
+		"ECB-AES128",
+		"2b7e151628aed2a6abf7158809cf4f3c",
+		"6bc1bee22e409f96e93d7e117393172aae2d8a571e03ac9c9eb76fac45af8e5130c81c46a35ce411e5fbc1191a0a52eff69f2445df4f9b17ad2b417be66c3710",
+		"3ad77bb40d7a3660a89ecaf32466ef97f5d3d58503b9699de785895a96fdbaaf43b1cd7f598ece23881b00e3ed0306887b0c785e27e8ad3f8223207104725dd4",
+	},
+	{
+		"ECB-AES192",
+		"8e73b0f7da0e6452c810f32b809079e562f8ead2522c6b7b",
+		"6bc1bee22e409f96e93d7e117393172aae2d8a571e03ac9c9eb76fac45af8e5130c81c46a35ce411e5fbc1191a0a52eff69f2445df4f9b17ad2b417be66c3710",
+		"bd334f1d6e45f25ff712a214571fa5cc974104846d0ad3ad7734ecb3ecee4eefef7afd2270e2e60adce0ba2face6444e9a4b41ba738d6c72fb16691603c18e0e",
+	},
+	{
+		"ECB-AES256",
+		"603deb1015ca71be2b73aef0857d77811f352c073b6108d72d9810a30914dff4",
+		"6bc1bee22e409f96e93d7e117393172aae2d8a571e03ac9c9eb76fac45af8e5130c81c46a35ce411e5fbc1191a0a52eff69f2445df4f9b17ad2b417be66c3710",
+		"f3eed1bdb5d2a03c064b5a7e3db181f8591ccb10d410ed26dc5ba74a31362870b6ed21b99ca6f4f9f153e7b1beafed1d23304b7a39f9f3ff067d8d8f9e24ecc7",
+	},
+}
+
+func TestECBAES(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	for _, test := range ecbAESTests {
+		key, _ := hex.DecodeString(test.key)
+		plaintext, _ := hex.DecodeString(test.plaintext)
+		ciphertext, _ := hex.DecodeString(test.ciphertext)
+
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data := make([]byte, len(plaintext))
+		if err := cipher.NewECBEncrypter(block).CryptBlocks(data, plaintext); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if !bytes.Equal(data, ciphertext) {
+			t.Errorf("%s: CryptBlocks\nhave %x\nwant %x", test.name, data, ciphertext)
+		}
+
+		// In place
+		if err := cipher.NewECBDecrypter(block).CryptBlocks(data, data); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if !bytes.Equal(data, plaintext) {
+			t.Errorf("%s: CryptBlocks decrypt\nhave %x\nwant %x", test.name, data, plaintext)
+		}
+
+		// Each block on its own
+		for i := 0; i < len(plaintext); i += aes.BlockSize {
+			var b [aes.BlockSize]byte
+			if err := cipher.EncryptBlock(block, b[:], plaintext[i:i+aes.BlockSize]); err != nil {
+				t.Fatalf("%s: %v", test.name, err)
+			}
+			if !bytes.Equal(b[:], ciphertext[i:i+aes.BlockSize]) {
+				t.Errorf("%s: EncryptBlock %d\nhave %x\nwant %x", test.name, i/aes.BlockSize, b, ciphertext[i:i+aes.BlockSize])
+			}
+			if err := cipher.DecryptBlock(block, b[:], b[:]); err != nil {
+				t.Fatalf("%s: %v", test.name, err)
+			}
+			if !bytes.Equal(b[:], plaintext[i:i+aes.BlockSize]) {
+				t.Errorf("%s: DecryptBlock %d\nhave %x\nwant %x", test.name, i/aes.BlockSize, b, plaintext[i:i+aes.BlockSize])
+			}
+		}
+
+		// Many blocks in one call; ECB is independent of any IV set for CBC
+		long := bytes.Repeat(plaintext, 9)
+		block.SetIV(plaintext[:aes.BlockSize])
+		if err := cipher.EncryptBlocks(block, long, long); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if want := bytes.Repeat(ciphertext, 9); !bytes.Equal(long, want) {
+			t.Errorf("%s: EncryptBlocks of %d blocks differs", test.name, len(long)/aes.BlockSize)
+		}
+	}
+}
+
+func TestECBAESInvalid(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	block, err := aes.NewCipher(make([]byte, 16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 48)
+	if err := cipher.EncryptBlock(block, buf, buf[:17]); err == nil {
+		t.Error("EncryptBlock accepted more than one block")
+	}
+	if err := cipher.DecryptBlock(block, buf[:8], buf[:16]); err == nil {
+		t.Error("DecryptBlock accepted a short destination")
+	}
+	if err := cipher.EncryptBlocks(block, buf, buf[:20]); err == nil {
+		t.Error("EncryptBlocks accepted a partial block")
+	}
+	if err := cipher.DecryptBlocks(block, buf[:16], buf[:32]); err == nil {
+		t.Error("DecryptBlocks accepted a short destination")
+	}
+	if err := cipher.EncryptBlocks(block, nil, nil); err != nil {
+		t.Errorf("EncryptBlocks of nothing: %v", err)
+	}
+
+	// XTS-256 keys have no ECB mode
+	xts, err := aes.NewCipher(make([]byte, 64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cipher.EncryptBlock(xts, buf[:16], buf[:16]); err == nil {
+		t.Error("EncryptBlock accepted an XTS key")
+	}
+}
@@ -0,0 +1,371 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cipher_test
+
+import (
+	"bytes"
+	gaes "crypto/aes"
+	gcipher "crypto/cipher"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cipher"
+)
+
+// Differential fuzz targets. Each target checks this package against
+// crypto/aes and crypto/cipher (or a reference XTS built on crypto/aes),
+// including round trips, aliasing, empty inputs, odd lengths and short
+// buffers. Seed corpora live in testdata/fuzz so that regressions
+// reproduce deterministically with a plain go test.
+
+// fuzzKey stretches or truncates raw fuzz input to one of the given key
+// sizes so that every input exercises the cipher.
+func fuzzKey(raw []byte, sizes ...int) []byte {
+	size := sizes[len(raw)%len(sizes)]
+	key := make([]byte, size)
+	for i := range key {
+		if len(raw) > 0 {
+			key[i] = raw[i%len(raw)]
+		}
+	}
+
+	return key
+}
+
+// fuzzIV pads or truncates raw fuzz input to n bytes.
+func fuzzIV(raw []byte, n int) []byte {
+	iv := make([]byte, n)
+	copy(iv, raw)
+
+	return iv
+}
+
+func FuzzCBC(f *testing.F) {
+	f.Add(commonKey128, commonIV, commonInput, uint8(0))
+	f.Add(commonKey192, commonIV, commonInput[:16], uint8(0))
+	f.Add(commonKey256, commonIV, []byte{}, uint8(0))
+	f.Add(commonKey128, commonIV, commonInput[:17], uint8(0))
+	f.Add(commonKey256, commonIV, commonInput, uint8(16))
+
+	f.Fuzz(func(t *testing.T, rawKey, rawIV, src []byte, shrink uint8) {
+		if !aes.IsSupported() {
+			t.Skip("AES-NI not supported")
+		}
+
+		key := fuzzKey(rawKey, 16, 24, 32)
+		iv := fuzzIV(rawIV, aes.BlockSize)
+
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gblock, err := gaes.NewCipher(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Partial blocks and short destinations must be rejected
+		// rather than handed to ISA-L.
+		if int(shrink) > 0 && int(shrink) <= len(src) {
+			dst := make([]byte, len(src)-int(shrink))
+			if err := cipher.NewCBCEncrypter(block, iv).CryptBlocks(dst, src); err == nil {
+				t.Fatalf("CBC encrypt into %d byte buffer for %d bytes succeeded", len(dst), len(src))
+			}
+			if err := cipher.NewCBCDecrypter(block, iv).CryptBlocks(dst, src); err == nil {
+				t.Fatalf("CBC decrypt into %d byte buffer for %d bytes succeeded", len(dst), len(src))
+			}
+			return
+		}
+		if len(src)%aes.BlockSize != 0 {
+			dst := make([]byte, len(src))
+			if err := cipher.NewCBCEncrypter(block, iv).CryptBlocks(dst, src); err == nil {
+				t.Fatalf("CBC encrypt of %d bytes succeeded", len(src))
+			}
+			if err := cipher.NewCBCDecrypter(block, iv).CryptBlocks(dst, src); err == nil {
+				t.Fatalf("CBC decrypt of %d bytes succeeded", len(src))
+			}
+			return
+		}
+
+		want := make([]byte, len(src))
+		gcipher.NewCBCEncrypter(gblock, iv).CryptBlocks(want, src)
+
+		got := make([]byte, len(src))
+		if err := cipher.NewCBCEncrypter(block, iv).CryptBlocks(got, src); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("CBC encrypt\nhave %x\nwant %x", got, want)
+		}
+
+		inPlace := append([]byte(nil), src...)
+		if err := cipher.NewCBCEncrypter(block, iv).CryptBlocks(inPlace, inPlace); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(inPlace, want) {
+			t.Fatalf("CBC encrypt in place\nhave %x\nwant %x", inPlace, want)
+		}
+
+		plain := make([]byte, len(src))
+		if err := cipher.NewCBCDecrypter(block, iv).CryptBlocks(plain, got); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(plain, src) {
+			t.Fatalf("CBC decrypt\nhave %x\nwant %x", plain, src)
+		}
+
+		if err := cipher.NewCBCDecrypter(block, iv).CryptBlocks(inPlace, inPlace); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(inPlace, src) {
+			t.Fatalf("CBC decrypt in place\nhave %x\nwant %x", inPlace, src)
+		}
+	})
+}
+
+func FuzzGCM(f *testing.F) {
+	f.Add(commonKey128, []byte("0123456789ab"), commonInput, []byte("additional"), []byte{})
+	f.Add(commonKey256, []byte("0123456789ab"), []byte{}, []byte{}, []byte{})
+	f.Add(commonKey128, []byte{}, commonInput[:13], []byte{}, []byte("prefix"))
+	f.Add(commonKey256, []byte("nonce"), commonInput[:1], commonInput, []byte{0})
+
+	f.Fuzz(func(t *testing.T, rawKey, rawNonce, plaintext, ad, prefix []byte) {
+		if !aes.IsSupported() {
+			t.Skip("AES-NI not supported")
+		}
+
+		// GCM-192 and non-standard nonces are not implemented.
+		key := fuzzKey(rawKey, 16, 32)
+		nonce := fuzzIV(rawNonce, 12)
+
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gblock, err := gaes.NewCipher(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gaead, err := gcipher.NewGCM(gblock)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := gaead.Seal(append([]byte(nil), prefix...), nonce, plaintext, ad)
+		got := aead.Seal(append([]byte(nil), prefix...), nonce, plaintext, ad)
+		if !bytes.Equal(got, want) {
+			t.Fatalf("Seal\nhave %x\nwant %x", got, want)
+		}
+		ct := got[len(prefix):]
+
+		// Seal in place over the plaintext's storage
+		buf := make([]byte, len(plaintext), len(plaintext)+aead.Overhead())
+		copy(buf, plaintext)
+		if sealed := aead.Seal(buf[:0], nonce, buf, ad); !bytes.Equal(sealed, ct) {
+			t.Fatalf("Seal in place\nhave %x\nwant %x", sealed, ct)
+		}
+
+		pt, err := aead.Open(append([]byte(nil), prefix...), nonce, ct, ad)
+		if err != nil {
+			t.Fatalf("Open: %v", err)
+		}
+		if !bytes.Equal(pt[:len(prefix)], prefix) || !bytes.Equal(pt[len(prefix):], plaintext) {
+			t.Fatalf("Open\nhave %x\nwant %x%x", pt, prefix, plaintext)
+		}
+
+		inPlace := append([]byte(nil), ct...)
+		pt, err = aead.Open(inPlace[:0], nonce, inPlace, ad)
+		if err != nil {
+			t.Fatalf("Open in place: %v", err)
+		}
+		if !bytes.Equal(pt, plaintext) {
+			t.Fatalf("Open in place\nhave %x\nwant %x", pt, plaintext)
+		}
+
+		// Any single bit flip in the ciphertext or tag must be rejected
+		tampered := append([]byte(nil), ct...)
+		tampered[len(tampered)-1-len(plaintext)%len(tampered)] ^= 0x01
+		if _, err := aead.Open(nil, nonce, tampered, ad); err == nil {
+			t.Fatal("Open succeeded on tampered ciphertext")
+		}
+		if _, err := gaead.Open(nil, nonce, tampered, ad); err == nil {
+			t.Fatal("crypto/cipher Open succeeded on tampered ciphertext")
+		}
+
+		// Truncated inputs are never valid
+		if _, err := aead.Open(nil, nonce, ct[:len(ct)%aead.Overhead()], ad); err == nil {
+			t.Fatal("Open succeeded on truncated ciphertext")
+		}
+	})
+}
+
+// xtsReference is a plain IEEE 1619 XTS-AES implementation on crypto/aes,
+// including ciphertext stealing for partial final blocks.
+func xtsReference(key, tweak, dst, src []byte, encrypt bool) {
+	k1, _ := gaes.NewCipher(key[:len(key)/2])
+	k2, _ := gaes.NewCipher(key[len(key)/2:])
+
+	var t [aes.BlockSize]byte
+	k2.Encrypt(t[:], tweak)
+
+	next := func(t [aes.BlockSize]byte) [aes.BlockSize]byte {
+		var carry byte
+		for i := range t {
+			c := t[i] >> 7
+			t[i] = t[i]<<1 | carry
+			carry = c
+		}
+		if carry != 0 {
+			t[0] ^= 0x87
+		}
+		return t
+	}
+	crypt := func(out, in []byte, t [aes.BlockSize]byte) {
+		var b [aes.BlockSize]byte
+		for i := range b {
+			b[i] = in[i] ^ t[i]
+		}
+		if encrypt {
+			k1.Encrypt(b[:], b[:])
+		} else {
+			k1.Decrypt(b[:], b[:])
+		}
+		for i := range b {
+			out[i] = b[i] ^ t[i]
+		}
+	}
+
+	in := append([]byte(nil), src...)
+	full := len(in) &^ (aes.BlockSize - 1)
+	tail := len(in) - full
+	last := full
+	if tail != 0 {
+		last -= aes.BlockSize
+	}
+
+	for i := 0; i < last; i += aes.BlockSize {
+		crypt(dst[i:], in[i:], t)
+		t = next(t)
+	}
+	if tail == 0 {
+		return
+	}
+
+	// Ciphertext stealing: decryption uses the tweaks of the last two
+	// blocks in reverse order.
+	t1, t2 := t, next(t)
+	if !encrypt {
+		t1, t2 = t2, t1
+	}
+	var cc, pp [aes.BlockSize]byte
+	crypt(cc[:], in[last:], t1)
+	copy(pp[:], in[full:])
+	copy(pp[tail:], cc[tail:])
+	copy(dst[full:], cc[:tail])
+	crypt(dst[last:], pp[:], t2)
+}
+
+func FuzzXTS(f *testing.F) {
+	f.Add(make([]byte, 32), make([]byte, 16), make([]byte, 32), uint8(0))
+	f.Add(make([]byte, 64), commonIV, commonInput, uint8(0))
+	f.Add(commonKey256, commonIV, commonInput[:17], uint8(0))
+	f.Add(commonKey256, commonIV, commonInput[:15], uint8(0))
+	f.Add(commonKey256, commonIV, commonInput, uint8(1))
+
+	f.Fuzz(func(t *testing.T, rawKey, rawTweak, src []byte, shrink uint8) {
+		if !aes.IsSupported() {
+			t.Skip("AES-NI not supported")
+		}
+
+		key := fuzzKey(rawKey, 32, 64)
+		tweak := fuzzIV(rawTweak, aes.BlockSize)
+
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		x := cipher.NewXTSEncryptor(block)
+		x.SetIV(tweak)
+
+		if len(src) < aes.BlockSize {
+			dst := make([]byte, aes.BlockSize)
+			if err := x.Encrypt(dst, src); err == nil {
+				t.Fatalf("XTS encrypt of %d bytes succeeded", len(src))
+			}
+			return
+		}
+		if int(shrink) > 0 && int(shrink) <= len(src) {
+			dst := make([]byte, len(src)-int(shrink))
+			if err := x.Encrypt(dst, src); err == nil {
+				t.Fatalf("XTS encrypt into %d byte buffer for %d bytes succeeded", len(dst), len(src))
+			}
+			if err := x.Decrypt(dst, src); err == nil {
+				t.Fatalf("XTS decrypt into %d byte buffer for %d bytes succeeded", len(dst), len(src))
+			}
+			return
+		}
+
+		want := make([]byte, len(src))
+		xtsReference(key, tweak, want, src, true)
+
+		got := make([]byte, len(src))
+		if err := x.Encrypt(got, src); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("XTS encrypt\nhave %x\nwant %x", got, want)
+		}
+
+		plain := make([]byte, len(src))
+		if err := x.Decrypt(plain, got); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(plain, src) {
+			t.Fatalf("XTS decrypt\nhave %x\nwant %x", plain, src)
+		}
+
+		ref := make([]byte, len(src))
+		xtsReference(key, tweak, ref, got, false)
+		if !bytes.Equal(ref, src) {
+			t.Fatalf("reference XTS decrypt\nhave %x\nwant %x", ref, src)
+		}
+
+		inPlace := append([]byte(nil), src...)
+		if err := x.Encrypt(inPlace, inPlace); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(inPlace, want) {
+			t.Fatalf("XTS encrypt in place\nhave %x\nwant %x", inPlace, want)
+		}
+		if err := x.Decrypt(inPlace, inPlace); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(inPlace, src) {
+			t.Fatalf("XTS decrypt in place\nhave %x\nwant %x", inPlace, src)
+		}
+	})
+}
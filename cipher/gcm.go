@@ -27,6 +27,8 @@ package cipher
 import (
 	"crypto/subtle"
 	"errors"
+
+	"github.com/surendarchandra/crypto/internal/alias"
 )
 
 // AEAD is a cipher mode providing authenticated encryption with associated
@@ -79,6 +81,11 @@ type gcm struct {
 // An exception is when the underlying Block was created by aes.NewCipher
 // on systems with hardware support for AES. See the crypto/aes package documentation for details.
 func NewGCM(block Block) (AEAD, error) {
+	// Blocks without GCM support (AES-192 and XTS keys) have no tag
+	if block.GCMGetAuthTag() == nil {
+		return nil, errors.New("cipher: GCM not supported for this key size")
+	}
+
 	return &gcm{block: block, nonceSize: gcmStandardNonceSize}, nil
 }
 
@@ -116,13 +123,13 @@ func (g *gcm) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
 		panic("cipher: message too large for GCM")
 	}
 
-	ret, _ := sliceForAppend(dst, len(plaintext)+gcmTagSize)
+	ret, out := alias.SliceForAppend(dst, len(plaintext)+gcmTagSize)
 
 	g.block.SetIV(nonce)
 	g.block.GCMAddAdditionalData(additionalData)
-	g.block.Encrypt(ret, plaintext, ModeGCM)
+	g.block.Encrypt(out, plaintext, ModeGCM)
 	// copy authentication tag
-	copy(ret[len(plaintext):], g.block.GCMGetAuthTag())
+	copy(out[len(plaintext):], g.block.GCMGetAuthTag())
 
 	return ret
 }
@@ -144,11 +151,11 @@ func (g *gcm) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error
 	tag := ciphertext[len(ciphertext)-gcmTagSize:]
 	ciphertext = ciphertext[:len(ciphertext)-gcmTagSize]
 
-	ret, out := sliceForAppend(dst, len(ciphertext))
+	ret, out := alias.SliceForAppend(dst, len(ciphertext))
 
 	g.block.SetIV(nonce)
 	g.block.GCMAddAdditionalData(additionalData)
-	g.block.Decrypt(ret, ciphertext, ModeGCM)
+	g.block.Decrypt(out, ciphertext, ModeGCM)
 
 	if subtle.ConstantTimeCompare(g.block.GCMGetAuthTag(), tag) != 1 {
 		for i := range out {
@@ -159,18 +166,3 @@ func (g *gcm) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error
 
 	return ret, nil
 }
-
-// sliceForAppend takes a slice and a requested number of bytes. It returns a
-// slice with the contents of the given slice followed by that many bytes and a
-// second slice that aliases into it and contains only the extra bytes. If the
-// original slice has sufficient capacity then no allocation is performed.
-func sliceForAppend(in []byte, n int) (head, tail []byte) {
-	if total := len(in) + n; cap(in) >= total {
-		head = in[:total]
-	} else {
-		head = make([]byte, total)
-		copy(head, in)
-	}
-	tail = head[len(in):]
-	return
-}
@@ -0,0 +1,79 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cipher
+
+import (
+	"crypto/subtle"
+	"errors"
+)
+
+// GMAC is GCM authenticating data alone (SP 800-38D, RFC 4543): the tag
+// of a GCM encryption of no plaintext with the data as additional data.
+// It is keyed from the GCM key data of the block, so the hashing runs in
+// the accelerated GHASH.
+//
+// Each message needs a fresh nonce; like GCM, reusing a nonce under a key
+// allows forgeries. The nonce is an argument of every Tag and Verify
+// rather than state, so GMAC is not a hash.Hash, whose Sum may be called
+// again and whose Reset starts over.
+type GMAC struct {
+	block Block
+}
+
+// NewGMAC returns GMAC for a block with GCM support.
+func NewGMAC(block Block) (*GMAC, error) {
+	if _, err := NewGCM(block); err != nil {
+		return nil, err
+	}
+
+	return &GMAC{block: block}, nil
+}
+
+// Tag returns the 16 byte tag of data under the 12 byte nonce.
+func (g *GMAC) Tag(nonce, data []byte) ([]byte, error) {
+	if len(nonce) != gcmStandardNonceSize {
+		return nil, errors.New("cipher: incorrect nonce length given to GMAC")
+	}
+
+	// The GCM mode takes the address of the output even when there is
+	// no plaintext
+	var out [gcmTagSize]byte
+	g.block.SetIV(nonce)
+	g.block.GCMAddAdditionalData(data)
+	if err := g.block.Encrypt(out[:], nil, ModeGCM); err != nil {
+		return nil, err
+	}
+
+	return append([]byte(nil), g.block.GCMGetAuthTag()...), nil
+}
+
+// Verify reports whether tag is the tag of data under nonce, comparing in
+// constant time.
+func (g *GMAC) Verify(nonce, data, tag []byte) (bool, error) {
+	sum, err := g.Tag(nonce, data)
+	if err != nil {
+		return false, err
+	}
+
+	return subtle.ConstantTimeCompare(sum, tag) == 1, nil
+}
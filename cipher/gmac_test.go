@@ -0,0 +1,159 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cipher_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cipher"
+)
+
+// GCM encryptions with no plaintext from the NIST gcmEncryptExtIV128
+// vectors, and test cases 1 and 13 of the GCM specification
+var aesGMACTests = []struct {
+	key, nonce, ad, tag string
+}{
+	{
+		"11754cd72aec309bf52f7687212e8957",
+		"3c819d9a9bed087615030b65",
+		"",
+		"250327c674aaf477aef2675748cf6971",
+	},
+	{
+		"fbe3467cc254f81be8e78d765a2e6333",
+		"c6697351ff4aec29cdbaabf2",
+		"67",
+		"3659cdc25288bf499ac736c03bfc1159",
+	},
+	{
+		"8a7f9d80d08ad0bd5a20fb689c88f9fc",
+		"88b7b27d800937fda4f47301",
+		"50edd0503e0d7b8c91608eb5a1",
+		"ed6f65322a4740011f91d2aae22dd44e",
+	},
+	{
+		"77be63708971c4e240d1cb79e8d77feb",
+		"e0e00f19fed7ba0136a797f3",
+		"7a43ec1d9c0a5a78a0b16533a6213cab",
+		"209fcc8d3675ed938e9c7166709dd946",
+	},
+	{
+		"051758e95ed4abb2cdc69bb454110e82",
+		"c99a66320db73158a35a255d",
+		"67c6697351ff4aec29cdbaabf2fbe3467cc254f81be8e78d765a2e63339f",
+		"6ce77f1a5616c505b6aec09420234036",
+	},
+	{
+		"00000000000000000000000000000000",
+		"000000000000000000000000",
+		"",
+		"58e2fccefa7e3061367f1d57a4e7455a",
+	},
+	{
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"000000000000000000000000",
+		"",
+		"530f8afbc74536b9a963b4f1c4cb738b",
+	},
+}
+
+func TestAESGMAC(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	for i, test := range aesGMACTests {
+		key, _ := hex.DecodeString(test.key)
+		nonce, _ := hex.DecodeString(test.nonce)
+		ad, _ := hex.DecodeString(test.ad)
+		tag, _ := hex.DecodeString(test.tag)
+
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		g, err := cipher.NewGMAC(block)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, err := g.Tag(nonce, ad); err != nil || !bytes.Equal(got, tag) {
+			t.Errorf("#%d: got %x, %v, want %x", i, got, err, tag)
+		}
+		if ok, err := g.Verify(nonce, ad, tag); err != nil || !ok {
+			t.Errorf("#%d: Verify returned %t, %v", i, ok, err)
+		}
+
+		tag[0] ^= 1
+		if ok, err := g.Verify(nonce, ad, tag); err != nil || ok {
+			t.Errorf("#%d: Verify of a modified tag returned %t, %v", i, ok, err)
+		}
+	}
+
+	// AES-192 has no GCM
+	block, err := aes.NewCipher(make([]byte, 24))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cipher.NewGMAC(block); err == nil {
+		t.Error("accepted an AES-192 key")
+	}
+}
+
+// TestGMACTagRepeats checks Tag depends only on its arguments, and on
+// nothing tagged before.
+func TestGMACTagRepeats(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	block, err := aes.NewCipher(make([]byte, 16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := cipher.NewGMAC(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nonce := make([]byte, 12)
+	first, err := g.Tag(nonce, []byte("first message"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.Tag(nonce[:8], nil); err == nil {
+		t.Error("Tag accepted an 8 byte nonce")
+	}
+	if _, err := g.Verify(nonce[:8], nil, first); err == nil {
+		t.Error("Verify accepted an 8 byte nonce")
+	}
+	if _, err := g.Tag(nonce, []byte("second message")); err != nil {
+		t.Fatal(err)
+	}
+	if again, err := g.Tag(nonce, []byte("first message")); err != nil || !bytes.Equal(again, first) {
+		t.Errorf("second tag of a message %x, %v, want %x", again, err, first)
+	}
+}
@@ -0,0 +1,297 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cipher
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"hash/maphash"
+	"io"
+	"math"
+	"sync"
+
+	"github.com/surendarchandra/crypto/internal/alias"
+)
+
+// A NonceSource supplies the nonces for one AEAD key. Implementations
+// are safe for concurrent use, so one source can serve several AEADs
+// created from the same key.
+type NonceSource interface {
+	// Next fills nonce with a nonce that has not been returned before
+	// for this key.
+	Next(nonce []byte) error
+}
+
+var (
+	// ErrNonceExhausted is returned when a counter nonce source has
+	// used every value of its invocation field.
+	ErrNonceExhausted = errors.New("cipher: nonce space exhausted")
+
+	// ErrDuplicateNonce is returned by a DuplicateNonceDetector for a
+	// nonce it has (probably) seen before.
+	ErrDuplicateNonce = errors.New("cipher: duplicate nonce")
+)
+
+type randomNonceSource struct {
+	rand io.Reader
+}
+
+// NewRandomNonceSource returns a source of random nonces read from r, or
+// from crypto/rand if r is nil. With 96-bit nonces, SP 800-38D limits a
+// key to 2^32 messages (see GCMRandomNonceLimits).
+func NewRandomNonceSource(r io.Reader) NonceSource {
+	if r == nil {
+		r = rand.Reader
+	}
+
+	return &randomNonceSource{rand: r}
+}
+
+func (s *randomNonceSource) Next(nonce []byte) error {
+	_, err := io.ReadFull(s.rand, nonce)
+	return err
+}
+
+// CounterStore persists the reservations of a counter nonce source.
+type CounterStore interface {
+	// Load returns the counter reserved for the key, zero for a key it
+	// has not seen. Every counter below it may have been used.
+	Load(keyID string) (uint64, error)
+
+	// Reserve records that counters below next may be used. It must be
+	// durable when it returns.
+	Reserve(keyID string, next uint64) error
+}
+
+// CounterNonceConfig configures NewCounterNonceSource.
+type CounterNonceConfig struct {
+	// Prefix is the fixed field, e.g. a device or connection id, up to
+	// 8 bytes. It must differ between the sources that share a key.
+	Prefix []byte
+
+	// NonceSize is the size of the nonces, 12 if zero.
+	NonceSize int
+
+	// Window is the number of counters reserved in Store at a time, 1024
+	// if zero. After a crash, the unused counters of the last window are
+	// skipped.
+	Window uint64
+
+	// KeyID names the key in Store.
+	KeyID string
+
+	// Store, if set, persists the counter. Without it the counter starts
+	// at zero, so the key must not have been used before.
+	Store CounterStore
+}
+
+type counterNonceSource struct {
+	mu       sync.Mutex
+	cfg      CounterNonceConfig
+	next     uint64
+	reserved uint64
+	limit    uint64
+}
+
+// NewCounterNonceSource returns a source of deterministic nonces, the
+// fixed prefix followed by a big endian invocation counter, as in SP
+// 800-38D section 8.2.1. Counters are reserved in Store a window at a
+// time, so that a restart never reuses a nonce.
+func NewCounterNonceSource(cfg CounterNonceConfig) (NonceSource, error) {
+	if cfg.NonceSize == 0 {
+		cfg.NonceSize = gcmStandardNonceSize
+	}
+	if cfg.Window == 0 {
+		cfg.Window = 1024
+	}
+	if len(cfg.Prefix) > 8 || cfg.NonceSize-len(cfg.Prefix) < 4 {
+		return nil, errors.New("cipher: nonce counter must be at least 32 bits with a prefix of at most 8 bytes")
+	}
+
+	// Counters run from zero up to, not including, limit
+	s := &counterNonceSource{cfg: cfg, limit: math.MaxUint64}
+	if bits := 8 * (cfg.NonceSize - len(cfg.Prefix)); bits < 64 {
+		s.limit = 1 << uint(bits)
+	}
+
+	if cfg.Store != nil {
+		var err error
+		if s.next, err = cfg.Store.Load(cfg.KeyID); err != nil {
+			return nil, err
+		}
+		s.reserved = s.next
+	}
+
+	return s, nil
+}
+
+func (s *counterNonceSource) Next(nonce []byte) error {
+	if len(nonce) != s.cfg.NonceSize {
+		return errors.New("cipher: incorrect nonce length")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.next >= s.limit {
+		return ErrNonceExhausted
+	}
+	if s.cfg.Store != nil && s.next >= s.reserved {
+		reserve := s.next + s.cfg.Window
+		if reserve < s.next || reserve > s.limit {
+			reserve = s.limit
+		}
+		if err := s.cfg.Store.Reserve(s.cfg.KeyID, reserve); err != nil {
+			return err
+		}
+		s.reserved = reserve
+	}
+
+	n := copy(nonce, s.cfg.Prefix)
+	for i := n; i < len(nonce); i++ {
+		nonce[i] = 0
+	}
+	var ctr [8]byte
+	binary.BigEndian.PutUint64(ctr[:], s.next)
+	if field := len(nonce) - n; field < 8 {
+		copy(nonce[n:], ctr[8-field:])
+	} else {
+		copy(nonce[len(nonce)-8:], ctr[:])
+	}
+	s.next++
+
+	return nil
+}
+
+// DuplicateNonceDetector is a debugging aid that remembers the nonces
+// used with one key in a Bloom filter. A nonce that was used before is
+// always reported; with the configured probability, a fresh one is too,
+// so a detection needs confirming before it is treated as a bug.
+type DuplicateNonceDetector struct {
+	mu     sync.Mutex
+	src    NonceSource
+	bits   []uint64
+	m, k   uint64
+	seed   [2]maphash.Seed
+	n, max uint64
+}
+
+// NewDuplicateNonceDetector returns a detector sized for n nonces at a
+// false positive rate of p. If src is not nil, Next takes nonces from it
+// and checks them.
+func NewDuplicateNonceDetector(src NonceSource, n uint64, p float64) (*DuplicateNonceDetector, error) {
+	if n == 0 || p <= 0 || p >= 1 {
+		return nil, errors.New("cipher: invalid duplicate nonce detector size")
+	}
+
+	// m = -n ln p / (ln 2)^2 bits and k = m/n ln 2 hashes
+	m := uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	k := uint64(math.Max(1, math.Round(float64(m)/float64(n)*math.Ln2)))
+
+	return &DuplicateNonceDetector{
+		src:  src,
+		bits: make([]uint64, (m+63)/64),
+		m:    m,
+		k:    k,
+		seed: [2]maphash.Seed{maphash.MakeSeed(), maphash.MakeSeed()},
+		max:  n,
+	}, nil
+}
+
+// Check records nonce and returns ErrDuplicateNonce if it was (probably)
+// recorded before.
+func (d *DuplicateNonceDetector) Check(nonce []byte) error {
+	// Double hashing: the i-th index is h1 + i*h2
+	h1 := maphash.Bytes(d.seed[0], nonce)
+	h2 := maphash.Bytes(d.seed[1], nonce) | 1
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	seen := true
+	for i := uint64(0); i < d.k; i++ {
+		bit := (h1 + i*h2) % d.m
+		if d.bits[bit/64]&(1<<(bit%64)) == 0 {
+			seen = false
+			d.bits[bit/64] |= 1 << (bit % 64)
+		}
+	}
+	if seen {
+		return ErrDuplicateNonce
+	}
+	d.n++
+
+	return nil
+}
+
+// Full reports whether the detector has recorded the number of nonces
+// it was sized for, past which the false positive rate climbs.
+func (d *DuplicateNonceDetector) Full() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.n >= d.max
+}
+
+// Next implements NonceSource, checking each nonce of the wrapped
+// source.
+func (d *DuplicateNonceDetector) Next(nonce []byte) error {
+	if d.src == nil {
+		return errors.New("cipher: duplicate nonce detector has no source")
+	}
+	if err := d.src.Next(nonce); err != nil {
+		return err
+	}
+
+	return d.Check(nonce)
+}
+
+// SealWithAutoNonce takes a nonce from src, seals plaintext with it and
+// appends the nonce followed by the sealed message to dst. If aead is a
+// UsageLimitedAEAD, a reached limit is returned as ErrRekeyRequired.
+func SealWithAutoNonce(aead AEAD, src NonceSource, dst, plaintext, additionalData []byte) ([]byte, error) {
+	ret, out := alias.SliceForAppend(dst, aead.NonceSize())
+	if err := src.Next(out); err != nil {
+		return nil, err
+	}
+	nonce := out
+
+	// Seal appends after the nonce, leaving it in place
+	if u, ok := aead.(*UsageLimitedAEAD); ok {
+		return u.TrySeal(ret, nonce, plaintext, additionalData)
+	}
+
+	return aead.Seal(ret, nonce, plaintext, additionalData), nil
+}
+
+// OpenWithAutoNonce opens a message sealed by SealWithAutoNonce and
+// appends the plaintext to dst.
+func OpenWithAutoNonce(aead AEAD, dst, ciphertext, additionalData []byte) ([]byte, error) {
+	n := aead.NonceSize()
+	if len(ciphertext) < n {
+		return nil, errOpen
+	}
+
+	return aead.Open(dst, ciphertext[:n], ciphertext[n:], additionalData)
+}
@@ -0,0 +1,223 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cipher_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cipher"
+)
+
+type memoryCounterStore map[string]uint64
+
+func (m memoryCounterStore) Load(id string) (uint64, error) {
+	return m[id], nil
+}
+
+func (m memoryCounterStore) Reserve(id string, next uint64) error {
+	m[id] = next
+	return nil
+}
+
+func TestCounterNonceSource(t *testing.T) {
+	store := memoryCounterStore{}
+	cfg := cipher.CounterNonceConfig{
+		Prefix: []byte{0xde, 0xad, 0xbe, 0xef},
+		Window: 4,
+		KeyID:  "k1",
+		Store:  store,
+	}
+	src, err := cipher.NewCounterNonceSource(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nonce := make([]byte, 12)
+	for i, want := range []string{
+		"deadbeef0000000000000000",
+		"deadbeef0000000000000001",
+		"deadbeef0000000000000002",
+		"deadbeef0000000000000003",
+		"deadbeef0000000000000004",
+	} {
+		if err := src.Next(nonce); err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(nonce); got != want {
+			t.Errorf("nonce %d: got %s, want %s", i, got, want)
+		}
+	}
+	if store["k1"] != 8 {
+		t.Errorf("reserved %d, want 8", store["k1"])
+	}
+
+	// A restart skips the rest of the reserved window
+	src, err = cipher.NewCounterNonceSource(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Next(nonce); err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(nonce); got != "deadbeef0000000000000008" {
+		t.Errorf("after restart got %s", got)
+	}
+}
+
+func TestCounterNonceExhausted(t *testing.T) {
+	store := memoryCounterStore{"k": 1<<32 - 2}
+	src, err := cipher.NewCounterNonceSource(cipher.CounterNonceConfig{
+		Prefix: make([]byte, 8),
+		KeyID:  "k",
+		Store:  store,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nonce := make([]byte, 12)
+	if err := src.Next(nonce); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Next(nonce); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(nonce[8:], []byte{0xff, 0xff, 0xff, 0xff}) {
+		t.Errorf("last nonce %x", nonce)
+	}
+	if err := src.Next(nonce); err != cipher.ErrNonceExhausted {
+		t.Errorf("got %v, want ErrNonceExhausted", err)
+	}
+
+	if _, err := cipher.NewCounterNonceSource(cipher.CounterNonceConfig{Prefix: make([]byte, 9)}); err == nil {
+		t.Error("accepted a 24-bit counter")
+	}
+}
+
+// repeatingSource returns the same nonce every other call.
+type repeatingSource struct{ n byte }
+
+func (r *repeatingSource) Next(nonce []byte) error {
+	for i := range nonce {
+		nonce[i] = r.n / 2
+	}
+	r.n++
+	return nil
+}
+
+func TestDuplicateNonceDetector(t *testing.T) {
+	d, err := cipher.NewDuplicateNonceDetector(&repeatingSource{}, 1000, 1e-6)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nonce := make([]byte, 12)
+	if err := d.Next(nonce); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Next(nonce); err != cipher.ErrDuplicateNonce {
+		t.Fatalf("got %v, want ErrDuplicateNonce", err)
+	}
+
+	// A counter never repeats, and at this size a false positive is
+	// very unlikely
+	src, err := cipher.NewCounterNonceSource(cipher.CounterNonceConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err = cipher.NewDuplicateNonceDetector(src, 1000, 1e-6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 1000; i++ {
+		if err := d.Next(nonce); err != nil {
+			t.Fatalf("nonce %d: %v", i, err)
+		}
+	}
+	if !d.Full() {
+		t.Error("detector not full after its capacity")
+	}
+}
+
+func TestSealWithAutoNonce(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	block, err := aes.NewCipher(make([]byte, 16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := []byte("attack at dawn")
+	prefix := []byte("prefix")
+	src := cipher.NewRandomNonceSource(nil)
+
+	a, err := cipher.SealWithAutoNonce(aead, src, prefix, msg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := cipher.SealWithAutoNonce(aead, src, nil, msg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(a, prefix) || len(a) != len(prefix)+12+len(msg)+16 {
+		t.Fatalf("unexpected output %x", a)
+	}
+	if bytes.Equal(a[len(prefix):], b) {
+		t.Fatal("two seals gave the same output")
+	}
+
+	for _, ct := range [][]byte{a[len(prefix):], b} {
+		pt, err := cipher.OpenWithAutoNonce(aead, nil, ct, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(pt, msg) {
+			t.Errorf("got %q, want %q", pt, msg)
+		}
+	}
+
+	if _, err := cipher.OpenWithAutoNonce(aead, nil, b[:11], nil); err == nil {
+		t.Error("opened a truncated message")
+	}
+
+	// A usage limit is reported rather than panicking
+	limited, err := cipher.NewUsageLimitedAEAD(aead, cipher.UsageConfig{Limits: cipher.UsageLimits{MaxMessages: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cipher.SealWithAutoNonce(limited, src, nil, msg, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cipher.SealWithAutoNonce(limited, src, nil, msg, nil); err != cipher.ErrRekeyRequired {
+		t.Errorf("got %v, want ErrRekeyRequired", err)
+	}
+}
@@ -0,0 +1,327 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cipher
+
+import (
+	"crypto/subtle"
+	"errors"
+	"math/bits"
+
+	"github.com/surendarchandra/crypto/internal/alias"
+	"github.com/surendarchandra/crypto/internal/gf128"
+)
+
+// ocb represents the OCB3 mode, RFC 7253.
+type ocb struct {
+	block     Block
+	nonceSize int
+	tagSize   int
+
+	lStar, lDollar [ocbBlockSize]byte
+	l              [64][ocbBlockSize]byte
+}
+
+// NewOCB returns the given 128-bit block cipher wrapped in OCB3 with the
+// given nonce and tag sizes. The nonce may be 1 to 15 bytes and the tag 1
+// to 16 bytes; RFC 7253 defines AEAD algorithms for 12 byte nonces with
+// 8, 12 and 16 byte tags.
+func NewOCB(block Block, nonceSize, tagSize int) (AEAD, error) {
+	if block.BlockSize() != ocbBlockSize {
+		return nil, errors.New("cipher: OCB requires a 128-bit block cipher")
+	}
+	if nonceSize < 1 || nonceSize > 15 {
+		return nil, errors.New("cipher: OCB nonce size must be between 1 and 15 bytes")
+	}
+	if tagSize < 1 || tagSize > 16 {
+		return nil, errors.New("cipher: OCB tag size must be between 1 and 16 bytes")
+	}
+
+	o := &ocb{block: block, nonceSize: nonceSize, tagSize: tagSize}
+
+	// OCB needs the ECB mode, which XTS keys lack
+	if err := EncryptBlock(block, o.lStar[:], o.lStar[:]); err != nil {
+		return nil, errors.New("cipher: OCB not supported for this key size")
+	}
+	o.lDollar = gf128.Double(o.lStar)
+	o.l[0] = gf128.Double(o.lDollar)
+	for i := 1; i < len(o.l); i++ {
+		o.l[i] = gf128.Double(o.l[i-1])
+	}
+
+	return o, nil
+}
+
+const (
+	ocbBlockSize = 16
+
+	// ocbChunkSize is how much is handed to the AES core at a time
+	ocbChunkSize = 4096
+)
+
+func (o *ocb) NonceSize() int {
+	return o.nonceSize
+}
+
+func (o *ocb) Overhead() int {
+	return o.tagSize
+}
+
+func xorBlock(dst, a, b []byte) {
+	for i := 0; i < ocbBlockSize; i++ {
+		dst[i] = a[i] ^ b[i]
+	}
+}
+
+// offset0 returns the initial offset for nonce (RFC 7253 section 4.2).
+func (o *ocb) offset0(nonce []byte) ([ocbBlockSize]byte, error) {
+	var n, ktop [ocbBlockSize]byte
+	n[0] = byte(o.tagSize * 8 % 128 << 1)
+	n[ocbBlockSize-1-len(nonce)] |= 1
+	copy(n[ocbBlockSize-len(nonce):], nonce)
+
+	bottom := uint(n[ocbBlockSize-1] & 63)
+	n[ocbBlockSize-1] &^= 63
+	if err := EncryptBlock(o.block, ktop[:], n[:]); err != nil {
+		return [ocbBlockSize]byte{}, err
+	}
+
+	var stretch [ocbBlockSize + 8]byte
+	copy(stretch[:], ktop[:])
+	for i := 0; i < 8; i++ {
+		stretch[ocbBlockSize+i] = ktop[i] ^ ktop[i+1]
+	}
+
+	var offset [ocbBlockSize]byte
+	shift, bit := bottom/8, bottom%8
+	for i := range offset {
+		offset[i] = stretch[uint(i)+shift]<<bit | stretch[uint(i)+shift+1]>>(8-bit)
+	}
+	return offset, nil
+}
+
+// crypt runs the whole blocks of src through OCB into dst, starting with
+// block index 1, and updates the offset and the plaintext checksum. The
+// offsets of a chunk are computed first so the chunk goes through the AES
+// core in one call.
+func (o *ocb) crypt(dst, src []byte, offset, checksum *[ocbBlockSize]byte, encrypt bool) error {
+	var offsets, in, out [ocbChunkSize]byte
+
+	index := uint64(1)
+	for len(src) > 0 {
+		n := len(src)
+		if n > ocbChunkSize {
+			n = ocbChunkSize
+		}
+
+		for i := 0; i < n; i += ocbBlockSize {
+			xorBlock(offset[:], offset[:], o.l[bits.TrailingZeros64(index)][:])
+			index++
+			copy(offsets[i:], offset[:])
+			xorBlock(in[i:], src[i:], offset[:])
+			if encrypt {
+				xorBlock(checksum[:], checksum[:], src[i:])
+			}
+		}
+
+		var err error
+		if encrypt {
+			err = EncryptBlocks(o.block, out[:n], in[:n])
+		} else {
+			err = DecryptBlocks(o.block, out[:n], in[:n])
+		}
+		if err != nil {
+			return err
+		}
+
+		for i := 0; i < n; i += ocbBlockSize {
+			xorBlock(dst[i:], out[i:], offsets[i:])
+			if !encrypt {
+				xorBlock(checksum[:], checksum[:], dst[i:])
+			}
+		}
+
+		dst, src = dst[n:], src[n:]
+	}
+
+	return nil
+}
+
+// hash computes HASH(K, A) (RFC 7253 section 4.1).
+func (o *ocb) hash(additionalData []byte) ([ocbBlockSize]byte, error) {
+	var sum, offset [ocbBlockSize]byte
+	var in, out [ocbChunkSize]byte
+
+	full := len(additionalData) / ocbBlockSize * ocbBlockSize
+	index := uint64(1)
+	for pos := 0; pos < full; {
+		n := full - pos
+		if n > ocbChunkSize {
+			n = ocbChunkSize
+		}
+
+		for i := 0; i < n; i += ocbBlockSize {
+			xorBlock(offset[:], offset[:], o.l[bits.TrailingZeros64(index)][:])
+			index++
+			xorBlock(in[i:], additionalData[pos+i:], offset[:])
+		}
+		if err := EncryptBlocks(o.block, out[:n], in[:n]); err != nil {
+			return sum, err
+		}
+		for i := 0; i < n; i += ocbBlockSize {
+			xorBlock(sum[:], sum[:], out[i:])
+		}
+
+		pos += n
+	}
+
+	if rest := additionalData[full:]; len(rest) > 0 {
+		var last [ocbBlockSize]byte
+		copy(last[:], rest)
+		last[len(rest)] = 0x80
+		xorBlock(offset[:], offset[:], o.lStar[:])
+		xorBlock(last[:], last[:], offset[:])
+		if err := EncryptBlock(o.block, last[:], last[:]); err != nil {
+			return sum, err
+		}
+		xorBlock(sum[:], sum[:], last[:])
+	}
+
+	return sum, nil
+}
+
+// tag finishes the tag from the final offset and checksum.
+func (o *ocb) tag(offset, checksum *[ocbBlockSize]byte, additionalData []byte) ([ocbBlockSize]byte, error) {
+	var tag [ocbBlockSize]byte
+
+	xorBlock(tag[:], checksum[:], offset[:])
+	xorBlock(tag[:], tag[:], o.lDollar[:])
+	if err := EncryptBlock(o.block, tag[:], tag[:]); err != nil {
+		return tag, err
+	}
+	sum, err := o.hash(additionalData)
+	if err != nil {
+		return tag, err
+	}
+	xorBlock(tag[:], tag[:], sum[:])
+
+	return tag, nil
+}
+
+func (o *ocb) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != o.nonceSize {
+		panic("cipher: incorrect nonce length given to OCB")
+	}
+
+	ret, out := alias.SliceForAppend(dst, len(plaintext)+o.tagSize)
+
+	offset, err := o.offset0(nonce)
+	if err != nil {
+		panic("cipher: OCB: " + err.Error())
+	}
+	var checksum [ocbBlockSize]byte
+	full := len(plaintext) / ocbBlockSize * ocbBlockSize
+	if err := o.crypt(out[:full], plaintext[:full], &offset, &checksum, true); err != nil {
+		panic("cipher: OCB: " + err.Error())
+	}
+
+	if rest := plaintext[full:]; len(rest) > 0 {
+		var pad, last [ocbBlockSize]byte
+		copy(last[:], rest)
+		last[len(rest)] = 0x80
+		xorBlock(checksum[:], checksum[:], last[:])
+
+		xorBlock(offset[:], offset[:], o.lStar[:])
+		if err := EncryptBlock(o.block, pad[:], offset[:]); err != nil {
+			panic("cipher: OCB: " + err.Error())
+		}
+		for i := range rest {
+			out[full+i] = rest[i] ^ pad[i]
+		}
+	}
+
+	tag, err := o.tag(&offset, &checksum, additionalData)
+	if err != nil {
+		panic("cipher: OCB: " + err.Error())
+	}
+	copy(out[len(plaintext):], tag[:o.tagSize])
+
+	return ret
+}
+
+func (o *ocb) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != o.nonceSize {
+		panic("cipher: incorrect nonce length given to OCB")
+	}
+	if len(ciphertext) < o.tagSize {
+		return nil, errOpen
+	}
+
+	var expected [ocbBlockSize]byte
+	copy(expected[:], ciphertext[len(ciphertext)-o.tagSize:])
+	ciphertext = ciphertext[:len(ciphertext)-o.tagSize]
+
+	ret, out := alias.SliceForAppend(dst, len(ciphertext))
+
+	tag, err := o.decrypt(out, nonce, ciphertext, additionalData)
+	if err == nil && subtle.ConstantTimeCompare(tag[:o.tagSize], expected[:o.tagSize]) != 1 {
+		err = errOpen
+	}
+	if err != nil {
+		for i := range out {
+			out[i] = 0
+		}
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// decrypt decrypts ciphertext into out and returns the tag it computes.
+func (o *ocb) decrypt(out, nonce, ciphertext, additionalData []byte) ([ocbBlockSize]byte, error) {
+	offset, err := o.offset0(nonce)
+	if err != nil {
+		return offset, err
+	}
+	var checksum [ocbBlockSize]byte
+	full := len(ciphertext) / ocbBlockSize * ocbBlockSize
+	if err := o.crypt(out[:full], ciphertext[:full], &offset, &checksum, false); err != nil {
+		return offset, err
+	}
+
+	if rest := ciphertext[full:]; len(rest) > 0 {
+		var pad, last [ocbBlockSize]byte
+		xorBlock(offset[:], offset[:], o.lStar[:])
+		if err := EncryptBlock(o.block, pad[:], offset[:]); err != nil {
+			return offset, err
+		}
+		for i := range rest {
+			out[full+i] = rest[i] ^ pad[i]
+		}
+
+		copy(last[:], out[full:])
+		last[len(rest)] = 0x80
+		xorBlock(checksum[:], checksum[:], last[:])
+	}
+
+	return o.tag(&offset, &checksum, additionalData)
+}
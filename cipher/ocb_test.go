@@ -0,0 +1,300 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cipher_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cipher"
+)
+
+// RFC 7253 appendix A sample results, with key 000102030405060708090a0b0c0d0e0f
+// and a 16 byte tag
+var aesOCBTests = []struct {
+	nonce, ad, plaintext, result string
+}{
+	{
+		"bbaa99887766554433221100",
+		"",
+		"",
+		"785407bfffc8ad9edcc5520ac9111ee6",
+	},
+	{
+		"bbaa99887766554433221101",
+		"0001020304050607",
+		"0001020304050607",
+		"6820b3657b6f615a5725bda0d3b4eb3a257c9af1f8f03009",
+	},
+	{
+		"bbaa99887766554433221102",
+		"0001020304050607",
+		"",
+		"81017f8203f081277152fade694a0a00",
+	},
+	{
+		"bbaa99887766554433221103",
+		"",
+		"0001020304050607",
+		"45dd69f8f5aae72414054cd1f35d82760b2cd00d2f99bfa9",
+	},
+	{
+		"bbaa99887766554433221104",
+		"000102030405060708090a0b0c0d0e0f",
+		"000102030405060708090a0b0c0d0e0f",
+		"571d535b60b277188be5147170a9a22c3ad7a4ff3835b8c5701c1ccec8fc3358",
+	},
+	{
+		"bbaa99887766554433221105",
+		"000102030405060708090a0b0c0d0e0f",
+		"",
+		"8cf761b6902ef764462ad86498ca6b97",
+	},
+	{
+		"bbaa99887766554433221106",
+		"",
+		"000102030405060708090a0b0c0d0e0f",
+		"5ce88ec2e0692706a915c00aeb8b2396f40e1c743f52436bdf06d8fa1eca343d",
+	},
+	{
+		"bbaa99887766554433221107",
+		"000102030405060708090a0b0c0d0e0f1011121314151617",
+		"000102030405060708090a0b0c0d0e0f1011121314151617",
+		"1ca2207308c87c010756104d8840ce1952f09673a448a122c92c62241051f57356d7f3c90bb0e07f",
+	},
+	{
+		"bbaa99887766554433221108",
+		"000102030405060708090a0b0c0d0e0f1011121314151617",
+		"",
+		"6dc225a071fc1b9f7c69f93b0f1e10de",
+	},
+	{
+		"bbaa99887766554433221109",
+		"",
+		"000102030405060708090a0b0c0d0e0f1011121314151617",
+		"221bd0de7fa6fe993eccd769460a0af2d6cded0c395b1c3ce725f32494b9f914d85c0b1eb38357ff",
+	},
+	{
+		"bbaa9988776655443322110a",
+		"000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+		"000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+		"bd6f6c496201c69296c11efd138a467abd3c707924b964deaffc40319af5a48540fbba186c5553c68ad9f592a79a4240",
+	},
+	{
+		"bbaa9988776655443322110b",
+		"000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+		"",
+		"fe80690bee8a485d11f32965bc9d2a32",
+	},
+	{
+		"bbaa9988776655443322110c",
+		"",
+		"000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+		"2942bfc773bda23cabc6acfd9bfd5835bd300f0973792ef46040c53f1432bcdfb5e1dde3bc18a5f840b52e653444d5df",
+	},
+	{
+		"bbaa9988776655443322110d",
+		"000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f2021222324252627",
+		"000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f2021222324252627",
+		"d5ca91748410c1751ff8a2f618255b68a0a12e093ff454606e59f9c1d0ddc54b65e8628e568bad7aed07ba06a4a69483a7035490c5769e60",
+	},
+	{
+		"bbaa9988776655443322110e",
+		"000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f2021222324252627",
+		"",
+		"c5cd9d1850c141e358649994ee701b68",
+	},
+	{
+		"bbaa9988776655443322110f",
+		"",
+		"000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f2021222324252627",
+		"4412923493c57d5de0d700f753cce0d1d2d95060122e9f15a5ddbfc5787e50b5cc55ee507bcb084e479ad363ac366b95a98ca5f3000b1479",
+	},
+}
+
+func TestAESOCB(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	key, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aead, err := cipher.NewOCB(block, 12, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, test := range aesOCBTests {
+		nonce, _ := hex.DecodeString(test.nonce)
+		ad, _ := hex.DecodeString(test.ad)
+		plaintext, _ := hex.DecodeString(test.plaintext)
+
+		ct := aead.Seal(nil, nonce, plaintext, ad)
+		if got := hex.EncodeToString(ct); got != test.result {
+			t.Errorf("#%d: got %s, want %s", i, got, test.result)
+			continue
+		}
+
+		pt, err := aead.Open(nil, nonce, ct, ad)
+		if err != nil {
+			t.Errorf("#%d: Open failed: %v", i, err)
+		} else if !bytes.Equal(pt, plaintext) {
+			t.Errorf("#%d: got %x, want %x", i, pt, plaintext)
+		}
+
+		// In place
+		buf := append([]byte(nil), plaintext...)
+		if ct2 := aead.Seal(buf[:0], nonce, buf, ad); !bytes.Equal(ct2, ct) {
+			t.Errorf("#%d: in place Seal differs", i)
+		}
+
+		ct[len(ct)-1] ^= 1
+		if _, err := aead.Open(nil, nonce, ct, ad); err == nil {
+			t.Errorf("#%d: opened a modified tag", i)
+		}
+	}
+}
+
+func TestAESOCBTagSize12(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	// The RFC 7253 appendix A sample with a 12 byte tag
+	key, _ := hex.DecodeString("0f0e0d0c0b0a09080706050403020100")
+	nonce, _ := hex.DecodeString("bbaa9988776655443322110d")
+	msg, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f2021222324252627")
+	want := "1792a4e31e0755fb03e31b22116e6c2ddf9efd6e33d536f1a0124b0a55bae884ed93481529c76b6ad0c515f4d1cdd4fdac4f02aa"
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aead, err := cipher.NewOCB(block, 12, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(aead.Seal(nil, nonce, msg, msg)); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+// TestAESOCBIterated runs the RFC 7253 appendix A iterated test, which
+// covers every message length up to 127 bytes, for each key and tag size.
+func TestAESOCBIterated(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	tests := []struct {
+		keySize, tagSize int
+		result           string
+	}{
+		{16, 16, "67e944d23256c5e0b6c61fa22fdf1ea2"},
+		{24, 16, "f673f2c3e7174aae7bae986ca9f29e17"},
+		{32, 16, "d90eb8e9c977c88b79dd793d7ffa161c"},
+		{16, 12, "77a3d8e73589158d25d01209"},
+		{24, 12, "05d56ead2752c86be6932c5e"},
+		{32, 12, "5458359ac23b0cba9e6330dd"},
+		{16, 8, "192c9b7bd90ba06a"},
+		{24, 8, "0066bc6e0ef34e24"},
+		{32, 8, "7d4ea5d445501cbe"},
+	}
+
+	for _, test := range tests {
+		key := make([]byte, test.keySize)
+		key[len(key)-1] = byte(test.tagSize * 8)
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		aead, err := cipher.NewOCB(block, 12, test.tagSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var c []byte
+		nonce := make([]byte, 12)
+		for i := 0; i < 128; i++ {
+			s := make([]byte, i)
+			nonce[10], nonce[11] = byte((3*i+1)>>8), byte(3*i+1)
+			c = aead.Seal(c, nonce, s, s)
+			nonce[10], nonce[11] = byte((3*i+2)>>8), byte(3*i+2)
+			c = aead.Seal(c, nonce, s, nil)
+			nonce[10], nonce[11] = byte((3*i+3)>>8), byte(3*i+3)
+			c = aead.Seal(c, nonce, nil, s)
+		}
+		nonce[10], nonce[11] = byte(385>>8), byte(385&0xff)
+		if got := hex.EncodeToString(aead.Seal(nil, nonce, nil, c)); got != test.result {
+			t.Errorf("key size %d tag size %d: got %s, want %s", test.keySize, test.tagSize, got, test.result)
+		}
+	}
+}
+
+func TestAESOCBParameters(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	block, err := aes.NewCipher(make([]byte, 16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range [][2]int{{0, 16}, {16, 16}, {12, 0}, {12, 17}} {
+		if _, err := cipher.NewOCB(block, p[0], p[1]); err == nil {
+			t.Errorf("accepted nonce size %d and tag size %d", p[0], p[1])
+		}
+	}
+
+	// Every nonce size must round trip, across the chunks handed to the
+	// AES core and with a partial last block
+	msg := bytes.Repeat([]byte("ocb"), 3001)
+	for n := 1; n <= 15; n++ {
+		aead, err := cipher.NewOCB(block, n, 16)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nonce := make([]byte, n)
+		ct := aead.Seal(nil, nonce, msg, msg[:5000])
+		if len(ct) != len(msg)+16 {
+			t.Errorf("nonce %d: output length %d", n, len(ct))
+		}
+		if pt, err := aead.Open(nil, nonce, ct, msg[:5000]); err != nil || !bytes.Equal(pt, msg) {
+			t.Errorf("nonce %d: round trip failed: %v", n, err)
+		}
+	}
+
+	// XTS keys have no CBC mode
+	xts, err := aes.NewCipher(make([]byte, 64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cipher.NewOCB(xts, 12, 16); err == nil {
+		t.Error("accepted an XTS-256 key")
+	}
+}
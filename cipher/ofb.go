@@ -0,0 +1,106 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cipher
+
+import (
+	"errors"
+)
+
+// ofbChunkSize bounds the key stream generated in a single call
+const ofbChunkSize = 4096
+
+// The OFB key stream (SP 800-38A section 6.4) is the chain of encryptions
+// of the IV, which is exactly the CBC encryption of zeros. Whole blocks of
+// key stream therefore come from the accelerated CBC in bulk.
+type ofb struct {
+	block Block
+
+	// next is the last key stream block, the input for the next one; out
+	// holds key stream and used the number of bytes of it consumed
+	next [16]byte
+	out  [ofbChunkSize]byte
+	used int
+	n    int
+
+	zero [ofbChunkSize]byte
+}
+
+// NewOFB returns a Stream that encrypts or decrypts with AES-OFB using
+// iv as the first block input.
+func NewOFB(b Block, iv []byte) (Stream, error) {
+	if b.BlockSize() != 16 {
+		return nil, errors.New("cipher: OFB requires a 128-bit block cipher")
+	}
+	if len(iv) != b.BlockSize() {
+		return nil, errors.New("cipher: OFB IV length must equal block size")
+	}
+
+	o := &ofb{block: b}
+	copy(o.next[:], iv)
+
+	// OFB needs the CBC mode, which XTS keys lack
+	b.SetIV(o.next[:])
+	if err := b.Encrypt(o.out[:16], o.zero[:16], ModeCBC); err != nil {
+		return nil, errors.New("cipher: OFB not supported for this key size")
+	}
+
+	return o, nil
+}
+
+// refill generates enough whole blocks of key stream for n bytes, up to a
+// chunk, into out.
+func (o *ofb) refill(n int) {
+	n = (n + 15) / 16 * 16
+	if n > ofbChunkSize {
+		n = ofbChunkSize
+	}
+
+	o.block.SetIV(o.next[:])
+	if err := o.block.Encrypt(o.out[:n], o.zero[:n], ModeCBC); err != nil {
+		panic("cipher: OFB: " + err.Error())
+	}
+	copy(o.next[:], o.out[n-16:n])
+	o.used, o.n = 0, n
+}
+
+func (o *ofb) XORKeyStream(dst, src []byte) {
+	if len(dst) < len(src) {
+		panic("cipher: output smaller than input")
+	}
+
+	for len(src) > 0 {
+		if o.used == o.n {
+			o.refill(len(src))
+		}
+
+		n := o.n - o.used
+		if n > len(src) {
+			n = len(src)
+		}
+		for i := 0; i < n; i++ {
+			dst[i] = src[i] ^ o.out[o.used+i]
+		}
+		o.used += n
+		dst, src = dst[n:], src[n:]
+	}
+}
@@ -0,0 +1,95 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cipher_test
+
+import (
+	"bytes"
+	gaes "crypto/aes"
+	gcipher "crypto/cipher"
+	"math/rand"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cipher"
+)
+
+func TestOFBAESAgainstGo(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for _, key := range [][]byte{commonKey128, commonKey192, commonKey256} {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gblock, _ := gaes.NewCipher(key)
+
+		for _, n := range []int{0, 1, 15, 16, 17, 64, 100, 4095, 4096, 4097, 10000} {
+			msg := make([]byte, n)
+			r.Read(msg)
+
+			want := make([]byte, n)
+			gcipher.NewOFB(gblock, commonIV).XORKeyStream(want, msg)
+			ofb, err := cipher.NewOFB(block, commonIV)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := xorInPieces(r, ofb, msg); !bytes.Equal(got, want) {
+				t.Errorf("key %d, %d bytes: differs from crypto/cipher", len(key), n)
+			}
+
+			// OFB is its own inverse
+			ofb, _ = cipher.NewOFB(block, commonIV)
+			if got := xorInPieces(r, ofb, want); !bytes.Equal(got, msg) {
+				t.Errorf("key %d, %d bytes: decryption failed", len(key), n)
+			}
+		}
+	}
+}
+
+func TestOFBParameters(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	block, err := aes.NewCipher(commonKey128)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range []int{0, 8, 17} {
+		if _, err := cipher.NewOFB(block, make([]byte, n)); err == nil {
+			t.Errorf("accepted a %d byte IV", n)
+		}
+	}
+
+	// XTS keys have no CBC mode
+	xts, err := aes.NewCipher(make([]byte, 64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cipher.NewOFB(xts, commonIV); err == nil {
+		t.Error("accepted an XTS key")
+	}
+}
@@ -0,0 +1,77 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cipher
+
+import (
+	"crypto/subtle"
+	"errors"
+)
+
+var errPadding = errors.New("cipher: invalid padding")
+
+// PKCS7Pad returns a copy of data padded to a multiple of blockSize, 1 to
+// 255, as in RFC 5652 section 6.3: n bytes of value n, where n is 1 to
+// blockSize. Aligned data gains a whole block of padding.
+func PKCS7Pad(data []byte, blockSize int) []byte {
+	if blockSize < 1 || blockSize > 255 {
+		panic("cipher: invalid PKCS#7 block size")
+	}
+
+	n := blockSize - len(data)%blockSize
+	padded := make([]byte, len(data)+n)
+	copy(padded, data)
+	for i := len(data); i < len(padded); i++ {
+		padded[i] = byte(n)
+	}
+
+	return padded
+}
+
+// PKCS7Unpad returns data, a non-empty multiple of blockSize, without
+// its PKCS#7 padding. The padding is checked in constant time, so the
+// time taken reveals nothing about it to a padding oracle attacker.
+// However, callers must still not reveal to an attacker whether
+// unpadding failed; authenticate ciphertexts where that matters.
+func PKCS7Unpad(data []byte, blockSize int) ([]byte, error) {
+	if blockSize < 1 || blockSize > 255 {
+		panic("cipher: invalid PKCS#7 block size")
+	}
+	if len(data) == 0 || len(data)%blockSize != 0 {
+		return nil, errPadding
+	}
+
+	n := data[len(data)-1]
+	good := subtle.ConstantTimeLessOrEq(1, int(n)) &
+		subtle.ConstantTimeLessOrEq(int(n), blockSize)
+	for i := 1; i <= blockSize; i++ {
+		// Bytes within the claimed padding must equal n
+		in := subtle.ConstantTimeLessOrEq(i, int(n))
+		eq := subtle.ConstantTimeByteEq(data[len(data)-i], n)
+		good &= eq | (in ^ 1)
+	}
+	if good != 1 {
+		return nil, errPadding
+	}
+
+	return data[:len(data)-int(n)], nil
+}
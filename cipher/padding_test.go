@@ -0,0 +1,77 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cipher_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/surendarchandra/crypto/cipher"
+)
+
+func TestPKCS7(t *testing.T) {
+	for _, blockSize := range []int{1, 8, 16, 255} {
+		for n := 0; n <= 2*blockSize+1; n++ {
+			msg := bytes.Repeat([]byte{0xa5}, n)
+			padded := cipher.PKCS7Pad(msg, blockSize)
+
+			pad := blockSize - n%blockSize
+			if len(padded) != n+pad || !bytes.Equal(padded[:n], msg) ||
+				!bytes.Equal(padded[n:], bytes.Repeat([]byte{byte(pad)}, pad)) {
+				t.Fatalf("block %d, %d bytes: bad padding %x", blockSize, n, padded[n:])
+			}
+
+			got, err := cipher.PKCS7Unpad(padded, blockSize)
+			if err != nil || !bytes.Equal(got, msg) {
+				t.Fatalf("block %d, %d bytes: unpad failed: %v", blockSize, n, err)
+			}
+		}
+	}
+}
+
+func TestPKCS7UnpadInvalid(t *testing.T) {
+	block := func(tail ...byte) []byte {
+		b := bytes.Repeat([]byte{0x10}, 32)
+		return append(b[:32-len(tail)], tail...)
+	}
+
+	for i, data := range [][]byte{
+		nil,
+		block()[:31],      // not whole blocks
+		block(0),          // zero padding byte
+		block(17),         // longer than a block
+		block(3, 2, 3),    // inconsistent padding
+		block(0x10, 0x0f), // inconsistent padding
+		block(4, 4, 4, 5), // wrong length byte
+		append(block()[:16:16], bytes.Repeat([]byte{0x11}, 16)...),
+	} {
+		if _, err := cipher.PKCS7Unpad(data, 16); err == nil {
+			t.Errorf("#%d: accepted %x", i, data)
+		}
+	}
+
+	// A whole block of padding
+	if got, err := cipher.PKCS7Unpad(block(), 16); err != nil || len(got) != 16 {
+		t.Errorf("full padding block: got %d bytes, %v", len(got), err)
+	}
+}
@@ -0,0 +1,260 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cipher
+
+import (
+	"errors"
+)
+
+// Usage is the running use of one AEAD key.
+type Usage struct {
+	// Messages is the number of messages sealed.
+	Messages uint64
+
+	// Blocks is the number of 16 byte blocks sealed, counting one
+	// block per message for the tag.
+	Blocks uint64
+
+	// Failures is the number of messages that failed to open.
+	Failures uint64
+}
+
+// UsageLimits bounds the use of one AEAD key. A zero field is not
+// limited.
+type UsageLimits struct {
+	// MaxMessages bounds the number of Seal calls.
+	MaxMessages uint64
+
+	// MaxBlocks bounds the data sealed, the confidentiality limit.
+	MaxBlocks uint64
+
+	// MaxFailures bounds the failed Open calls, the integrity limit.
+	MaxFailures uint64
+}
+
+// GCMRandomNonceLimits are the limits for AES-GCM with random 96-bit
+// nonces. SP 800-38D section 8.3 allows 2^32 invocations. The
+// confidentiality limit of 2^36 blocks (1 TiB) and the integrity limit of
+// 2^36 forgery attempts keep the attacker's advantage near 2^-57, as in
+// the CFRG AEAD limits draft, RFC 8446 section 5.5 and RFC 9147 section
+// 4.5.3.
+var GCMRandomNonceLimits = UsageLimits{
+	MaxMessages: 1 << 32,
+	MaxBlocks:   1 << 36,
+	MaxFailures: 1 << 36,
+}
+
+// GCMCounterNonceLimits are the limits for AES-GCM with deterministic
+// nonces, where the nonce space no longer bounds the message count.
+var GCMCounterNonceLimits = UsageLimits{
+	MaxBlocks:   1 << 36,
+	MaxFailures: 1 << 36,
+}
+
+// ErrRekeyRequired is returned once a key has reached one of its usage
+// limits. The key must be replaced before more messages are sealed.
+var ErrRekeyRequired = errors.New("cipher: key usage limit reached, rekey required")
+
+// UsageStore persists key usage so that limits hold across restarts.
+// Save is called after every change to the usage; implementations may
+// buffer writes but should flush before the process exits.
+type UsageStore interface {
+	// Load returns the usage recorded for the key, or a zero Usage for
+	// a key it has not seen.
+	Load(keyID string) (Usage, error)
+
+	// Save records the usage for the key.
+	Save(keyID string, u Usage) error
+}
+
+// UsageConfig configures NewUsageLimitedAEAD.
+type UsageConfig struct {
+	// Limits are the hard limits. Once one is reached, Seal (or Open
+	// for MaxFailures) stops working with ErrRekeyRequired.
+	Limits UsageLimits
+
+	// Threshold, between 0 and 1, is the fraction of any limit at which
+	// OnRekey is called, so that a new key can be rolled out before the
+	// hard limit. Zero means 1: the callback fires at the limit.
+	Threshold float64
+
+	// OnRekey, if set, is called once when usage first reaches the
+	// threshold.
+	OnRekey func(keyID string, u Usage)
+
+	// KeyID names the key in Store.
+	KeyID string
+
+	// Store, if set, persists the usage. Without it the count starts at
+	// zero for every new AEAD.
+	Store UsageStore
+}
+
+// UsageLimitedAEAD wraps an AEAD and counts how much it is used. Like
+// the AEADs it wraps, it is not safe for concurrent use.
+type UsageLimitedAEAD struct {
+	aead   AEAD
+	cfg    UsageConfig
+	soft   UsageLimits
+	usage  Usage
+	warned bool
+}
+
+// NewUsageLimitedAEAD returns aead wrapped to enforce cfg.Limits, with
+// the usage so far loaded from cfg.Store.
+func NewUsageLimitedAEAD(aead AEAD, cfg UsageConfig) (*UsageLimitedAEAD, error) {
+	if cfg.Threshold < 0 || cfg.Threshold > 1 {
+		return nil, errors.New("cipher: usage threshold must be between 0 and 1")
+	}
+	if cfg.Threshold == 0 {
+		cfg.Threshold = 1
+	}
+
+	u := &UsageLimitedAEAD{aead: aead, cfg: cfg}
+	u.soft = UsageLimits{
+		MaxMessages: scaleLimit(cfg.Limits.MaxMessages, cfg.Threshold),
+		MaxBlocks:   scaleLimit(cfg.Limits.MaxBlocks, cfg.Threshold),
+		MaxFailures: scaleLimit(cfg.Limits.MaxFailures, cfg.Threshold),
+	}
+
+	if cfg.Store != nil {
+		var err error
+		if u.usage, err = cfg.Store.Load(cfg.KeyID); err != nil {
+			return nil, err
+		}
+	}
+	u.warned = reached(u.usage, u.soft)
+
+	return u, nil
+}
+
+func scaleLimit(limit uint64, f float64) uint64 {
+	if limit == 0 || f == 1 {
+		return limit
+	}
+	if s := uint64(float64(limit) * f); s > 0 {
+		return s
+	}
+
+	return 1
+}
+
+// reached reports whether any counter is at or over its limit.
+func reached(u Usage, l UsageLimits) bool {
+	return (l.MaxMessages != 0 && u.Messages >= l.MaxMessages) ||
+		(l.MaxBlocks != 0 && u.Blocks >= l.MaxBlocks) ||
+		(l.MaxFailures != 0 && u.Failures >= l.MaxFailures)
+}
+
+// Usage returns the usage of the key so far.
+func (u *UsageLimitedAEAD) Usage() Usage {
+	return u.usage
+}
+
+// RekeyDue reports whether usage has reached the rekey threshold.
+func (u *UsageLimitedAEAD) RekeyDue() bool {
+	return reached(u.usage, u.soft)
+}
+
+// NonceSize returns the nonce size of the wrapped AEAD.
+func (u *UsageLimitedAEAD) NonceSize() int {
+	return u.aead.NonceSize()
+}
+
+// Overhead returns the overhead of the wrapped AEAD.
+func (u *UsageLimitedAEAD) Overhead() int {
+	return u.aead.Overhead()
+}
+
+// update counts a use, persists it and signals a due rekey.
+func (u *UsageLimitedAEAD) update(next Usage) error {
+	if u.cfg.Store != nil {
+		if err := u.cfg.Store.Save(u.cfg.KeyID, next); err != nil {
+			return err
+		}
+	}
+	u.usage = next
+
+	if !u.warned && reached(u.usage, u.soft) {
+		u.warned = true
+		if u.cfg.OnRekey != nil {
+			u.cfg.OnRekey(u.cfg.KeyID, u.usage)
+		}
+	}
+
+	return nil
+}
+
+// TrySeal is Seal that returns ErrRekeyRequired, without sealing, once
+// sealing the message would take the key past a limit. The usage is
+// recorded before the message is sealed, so an error from the store
+// also prevents sealing.
+func (u *UsageLimitedAEAD) TrySeal(dst, nonce, plaintext, additionalData []byte) ([]byte, error) {
+	next := u.usage
+	next.Messages++
+	next.Blocks += uint64(len(plaintext)+gcmBlockSize-1)/gcmBlockSize + 1
+
+	l := u.cfg.Limits
+	if (l.MaxMessages != 0 && next.Messages > l.MaxMessages) ||
+		(l.MaxBlocks != 0 && next.Blocks > l.MaxBlocks) {
+		return nil, ErrRekeyRequired
+	}
+
+	if err := u.update(next); err != nil {
+		return nil, err
+	}
+
+	return u.aead.Seal(dst, nonce, plaintext, additionalData), nil
+}
+
+// Seal implements AEAD. It panics where TrySeal would return an error,
+// as the AEAD interface has no way to report one.
+func (u *UsageLimitedAEAD) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	ret, err := u.TrySeal(dst, nonce, plaintext, additionalData)
+	if err != nil {
+		panic(err)
+	}
+
+	return ret
+}
+
+// Open implements AEAD. Failed opens count towards MaxFailures; once
+// that is reached, Open returns ErrRekeyRequired without trying.
+func (u *UsageLimitedAEAD) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	l := u.cfg.Limits
+	if l.MaxFailures != 0 && u.usage.Failures >= l.MaxFailures {
+		return nil, ErrRekeyRequired
+	}
+
+	ret, err := u.aead.Open(dst, nonce, ciphertext, additionalData)
+	if err != nil {
+		next := u.usage
+		next.Failures++
+		if serr := u.update(next); serr != nil {
+			return nil, serr
+		}
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,167 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cipher_test
+
+import (
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cipher"
+)
+
+type memoryUsageStore map[string]cipher.Usage
+
+func (m memoryUsageStore) Load(id string) (cipher.Usage, error) {
+	return m[id], nil
+}
+
+func (m memoryUsageStore) Save(id string, u cipher.Usage) error {
+	m[id] = u
+	return nil
+}
+
+func newUsageLimited(t *testing.T, cfg cipher.UsageConfig) *cipher.UsageLimitedAEAD {
+	t.Helper()
+
+	block, err := aes.NewCipher(make([]byte, 16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := cipher.NewUsageLimitedAEAD(aead, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return u
+}
+
+func TestUsageLimitMessages(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	var fired []cipher.Usage
+	store := memoryUsageStore{}
+	cfg := cipher.UsageConfig{
+		Limits:    cipher.UsageLimits{MaxMessages: 4},
+		Threshold: 0.5,
+		OnRekey:   func(id string, u cipher.Usage) { fired = append(fired, u) },
+		KeyID:     "k1",
+		Store:     store,
+	}
+	u := newUsageLimited(t, cfg)
+	nonce := make([]byte, u.NonceSize())
+
+	for i := 0; i < 3; i++ {
+		ct, err := u.TrySeal(nil, nonce, []byte("message"), nil)
+		if err != nil {
+			t.Fatalf("message %d: %v", i, err)
+		}
+		if _, err := u.Open(nil, nonce, ct, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(fired) != 1 || fired[0].Messages != 2 {
+		t.Fatalf("OnRekey calls %v, want one at 2 messages", fired)
+	}
+	if !u.RekeyDue() {
+		t.Error("RekeyDue false past the threshold")
+	}
+
+	// The count carries over to a new AEAD for the same key
+	u = newUsageLimited(t, cfg)
+	if got := u.Usage(); got.Messages != 3 || got.Blocks != 6 {
+		t.Fatalf("reloaded usage %+v", got)
+	}
+	if _, err := u.TrySeal(nil, nonce, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := u.TrySeal(nil, nonce, nil, nil); err != cipher.ErrRekeyRequired {
+		t.Fatalf("fifth message: got %v, want ErrRekeyRequired", err)
+	}
+	if len(fired) != 1 {
+		t.Errorf("OnRekey fired again after reload")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Seal past the limit did not panic")
+		}
+	}()
+	u.Seal(nil, nonce, nil, nil)
+}
+
+func TestUsageLimitBlocks(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	u := newUsageLimited(t, cipher.UsageConfig{Limits: cipher.UsageLimits{MaxBlocks: 10}})
+	nonce := make([]byte, u.NonceSize())
+
+	// 64 bytes is four blocks plus one for the tag
+	if _, err := u.TrySeal(nil, nonce, make([]byte, 64), nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := u.TrySeal(nil, nonce, make([]byte, 65), nil); err != cipher.ErrRekeyRequired {
+		t.Fatalf("got %v, want ErrRekeyRequired", err)
+	}
+	if _, err := u.TrySeal(nil, nonce, make([]byte, 64), nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := u.Usage(); got.Blocks != 10 || got.Messages != 2 {
+		t.Errorf("usage %+v", got)
+	}
+}
+
+func TestUsageLimitFailures(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	rekeyed := false
+	u := newUsageLimited(t, cipher.UsageConfig{
+		Limits:  cipher.UsageLimits{MaxFailures: 2},
+		OnRekey: func(string, cipher.Usage) { rekeyed = true },
+	})
+	nonce := make([]byte, u.NonceSize())
+	ct := u.Seal(nil, nonce, []byte("message"), nil)
+	bad := append([]byte(nil), ct...)
+	bad[0] ^= 1
+
+	for i := 0; i < 2; i++ {
+		if _, err := u.Open(nil, nonce, bad, nil); err == nil || err == cipher.ErrRekeyRequired {
+			t.Fatalf("forgery %d: got %v", i, err)
+		}
+	}
+	if !rekeyed {
+		t.Error("OnRekey not called at the failure limit")
+	}
+	if _, err := u.Open(nil, nonce, ct, nil); err != cipher.ErrRekeyRequired {
+		t.Fatalf("got %v, want ErrRekeyRequired", err)
+	}
+}
@@ -0,0 +1,195 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cipher_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cipher"
+	"github.com/surendarchandra/crypto/internal/wycheproof"
+)
+
+func TestWycheproofGCM(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	wycheproof.RunAEAD(t, "aes_gcm_test.json", func(key []byte, nonceSize, tagSize int) (cipher.AEAD, error) {
+		if tagSize != 16 {
+			return nil, errors.New("unsupported tag size")
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		return cipher.NewGCMWithNonceSize(block, nonceSize)
+	})
+}
+
+func TestWycheproofCBCPKCS5(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	var file wycheproof.IndCpaFile
+	wycheproof.Load(t, "aes_cbc_pkcs5_test.json", &file)
+
+	tally := wycheproof.NewTally(t)
+	defer tally.Report()
+
+	for _, group := range file.TestGroups {
+		for _, tc := range group.Tests {
+			block, err := aes.NewCipher(tc.Key)
+			if err != nil {
+				tally.Skip(tc.Test)
+				continue
+			}
+
+			match := true
+			if tc.Result == "valid" {
+				padded := cipher.PKCS7Pad(tc.Msg, aes.BlockSize)
+				ct := make([]byte, len(padded))
+				err := cipher.NewCBCEncrypter(block, tc.IV).CryptBlocks(ct, padded)
+				match = err == nil && bytes.Equal(ct, tc.Ct)
+			}
+
+			pt := make([]byte, len(tc.Ct))
+			err = cipher.NewCBCDecrypter(block, tc.IV).CryptBlocks(pt, tc.Ct)
+			if err == nil {
+				pt, err = cipher.PKCS7Unpad(pt, aes.BlockSize)
+			}
+			tally.Check(tc.Test, err == nil, match && bytes.Equal(pt, tc.Msg))
+		}
+	}
+}
+
+func TestWycheproofXTS(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	var file wycheproof.IndCpaFile
+	wycheproof.Load(t, "aes_xts_test.json", &file)
+
+	tally := wycheproof.NewTally(t)
+	defer tally.Report()
+
+	for _, group := range file.TestGroups {
+		for _, tc := range group.Tests {
+			// XTS with AES-192 keys is not implemented
+			if len(tc.Key) != 32 && len(tc.Key) != 64 {
+				tally.Skip(tc.Test)
+				continue
+			}
+			block, err := aes.NewCipher(tc.Key)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Short IVs are the low order bytes of the tweak
+			tweak := make([]byte, aes.BlockSize)
+			copy(tweak, tc.IV)
+
+			x := cipher.NewXTSEncryptor(block)
+			x.SetIV(tweak)
+
+			ct := make([]byte, len(tc.Msg))
+			err = x.Encrypt(ct, tc.Msg)
+			match := err == nil && bytes.Equal(ct, tc.Ct)
+
+			pt := make([]byte, len(tc.Ct))
+			err = x.Decrypt(pt, tc.Ct)
+			tally.Check(tc.Test, err == nil, match && bytes.Equal(pt, tc.Msg))
+		}
+	}
+}
+
+func TestWycheproofCCM(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	wycheproof.RunAEAD(t, "aes_ccm_test.json", func(key []byte, nonceSize, tagSize int) (cipher.AEAD, error) {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		return cipher.NewCCM(block, nonceSize, tagSize)
+	})
+}
+
+func TestWycheproofEAX(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	wycheproof.RunAEAD(t, "aes_eax_test.json", func(key []byte, nonceSize, tagSize int) (cipher.AEAD, error) {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		return cipher.NewEAX(block, nonceSize, tagSize)
+	})
+}
+
+func TestWycheproofGMAC(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	var file wycheproof.MacWithIVFile
+	wycheproof.Load(t, "aes_gmac_test.json", &file)
+
+	tally := wycheproof.NewTally(t)
+	defer tally.Report()
+
+	for _, group := range file.TestGroups {
+		for _, tc := range group.Tests {
+			// Only the standard nonce size is supported, as for GCM
+			if group.IVSize != 96 {
+				tally.Skip(tc.Test)
+				continue
+			}
+			block, err := aes.NewCipher(tc.Key)
+			if err != nil {
+				tally.Skip(tc.Test)
+				continue
+			}
+			g, err := cipher.NewGMAC(block)
+			if err != nil {
+				// GCM-192 is not supported
+				tally.Skip(tc.Test)
+				continue
+			}
+
+			ok, err := g.Verify(tc.IV, tc.Msg, tc.Tag)
+			if err != nil {
+				t.Fatalf("#%d: %v", tc.TcID, err)
+			}
+			tally.Check(tc.Test, ok, ok)
+		}
+	}
+}
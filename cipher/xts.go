@@ -70,7 +70,7 @@ func (x *xtsEncryptor) CryptBlocks(dst, src []byte) error {
 }
 
 func (x *xtsEncryptor) validate(dst, src []byte) error {
-	if len(dst) < x.block.BlockSize() {
+	if len(src) < x.block.BlockSize() {
 		return errors.New("Source buffer too small")
 	}
 	if len(dst) < len(src) {
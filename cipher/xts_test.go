@@ -53,34 +53,6 @@ func (x *CryptoXTSSuite) SetUpSuite(c *C) {
 	c.Assert(err, IsNil)
 }
 
-func (x *CryptoXTSSuite) TestXTS(c *C) {
-	if !aes.IsSupported() {
-		return
-	}
-
-	// Check against standard data for AES-XTS
-	for i, vector := range append(aesXts128TestVectors[:], aesXts256TestVectors[:]...) {
-		c.Logf("Testing vector #%d (key length: %d bits)\n", i, len(vector.key1)*8)
-
-		block, err := aes.NewCipher(append(vector.key1[:], vector.key2[:]...))
-		c.Assert(err, IsNil)
-
-		e := cipher.NewXTSEncryptor(block)
-		e.SetIV(vector.tweak)
-
-		ctx := make([]byte, len(vector.ctx), len(vector.ctx))
-		err = e.Encrypt(ctx, vector.ptx)
-		c.Assert(err, IsNil)
-		c.Assert(ctx, DeepEquals, vector.ctx)
-
-		ptx := make([]byte, len(vector.ptx), len(vector.ptx))
-
-		err = e.Decrypt(ptx, vector.ctx)
-		c.Assert(err, IsNil)
-		c.Assert(ptx, DeepEquals, vector.ptx)
-	}
-}
-
 func (x *CryptoXTSSuite) BenchmarkXTSEncrypt128(c *C) {
 	c.StopTimer()
 	c.Log("AES-XTS-128 Encrypt")
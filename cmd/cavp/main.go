@@ -0,0 +1,166 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Command cavp runs NIST CAVP and ACVP test files against this module.
+//
+//	cavp [-out dir] file...
+//
+// A CAVP request (.req) is answered with a response file (.rsp) in the
+// output directory. A CAVP response file is checked against its expected
+// values. An ACVP request (.json) is answered with a .response.json and,
+// when a .expected.json sits beside it, checked against that. The
+// .expected.json and .response.json files themselves are skipped, so a
+// directory can be given as dir/*.json.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cavp"
+)
+
+var outDir = flag.String("out", ".", "directory for response files")
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: %s [-out dir] file...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if !aes.IsSupported() {
+		fmt.Fprintln(os.Stderr, "cavp: AES-NI not supported")
+		os.Exit(1)
+	}
+
+	failed := false
+	for _, name := range flag.Args() {
+		var res *cavp.Result
+		var err error
+		switch {
+		case strings.HasSuffix(name, ".expected.json"), strings.HasSuffix(name, ".response.json"):
+			continue
+		case strings.HasSuffix(name, ".json"):
+			res, err = runACVP(name)
+		case strings.HasSuffix(name, ".req"), strings.HasSuffix(name, ".rsp"):
+			res, err = runCAVP(name)
+		default:
+			err = fmt.Errorf("unknown file type")
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+			failed = true
+			continue
+		}
+
+		for _, f := range res.Failures {
+			fmt.Println(f)
+		}
+		fmt.Printf("%s: %v\n", name, res)
+		if res.Failed > 0 {
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func runCAVP(name string) (*cavp.Result, error) {
+	fd, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	in, err := cavp.Parse(fd)
+	if err != nil {
+		return nil, err
+	}
+	out, res, err := cavp.Process(name, in)
+	if err != nil {
+		return nil, err
+	}
+
+	// Responses are only written for requests
+	if !strings.HasSuffix(name, ".req") {
+		return res, nil
+	}
+
+	base := strings.TrimSuffix(filepath.Base(name), ".req")
+	w, err := os.Create(filepath.Join(*outDir, base+".rsp"))
+	if err != nil {
+		return nil, err
+	}
+	if err := out.Write(w); err != nil {
+		w.Close()
+		return nil, err
+	}
+
+	return res, w.Close()
+}
+
+func runACVP(name string) (*cavp.Result, error) {
+	fd, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	var resp bytes.Buffer
+	res, err := cavp.ProcessACVP(fd, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	base := strings.TrimSuffix(filepath.Base(name), ".json")
+	if err := os.WriteFile(filepath.Join(*outDir, base+".response.json"), resp.Bytes(), 0644); err != nil {
+		return nil, err
+	}
+
+	expected, err := os.Open(strings.TrimSuffix(name, ".json") + ".expected.json")
+	if os.IsNotExist(err) {
+		return res, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer expected.Close()
+
+	checked, err := cavp.CheckACVP(&resp, expected)
+	if err != nil {
+		return nil, err
+	}
+	checked.Skipped = res.Skipped
+
+	return checked, nil
+}
@@ -0,0 +1,170 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package commitgcm implements a key-committing AES-GCM. Plain GCM is not
+// committing: a ciphertext can be crafted to open under two different
+// keys, which breaks multi-recipient and password-based schemes that
+// assume only one key can succeed.
+//
+// Each message derives, from the key and nonce, a 32 byte commitment and
+// a message key (the UtC transform of Bellare and Hoang with the CX
+// derivation): block i is E(K, N || i) XOR (N || i), computed with the
+// accelerated AES in a single ECB call. Blocks 1 and 2 are the commitment,
+// the following one or two blocks the AES-GCM message key. The sealed
+// message is the commitment followed by the GCM ciphertext and tag. Open
+// checks the commitment in constant time before GCM, so it fails under
+// any other key unless the derivation collides, which for a 256-bit
+// commitment takes about 2^128 work.
+//
+// The commitment binds the key and nonce, not the additional data.
+package commitgcm
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cipher"
+	"github.com/surendarchandra/crypto/internal/alias"
+)
+
+const (
+	// NonceSize is the size of the nonce.
+	NonceSize = 12
+
+	// CommitmentSize is the size of the key commitment prepended to each
+	// sealed message.
+	CommitmentSize = 32
+
+	// Overhead is the commitment and the GCM tag: 48 bytes.
+	Overhead = CommitmentSize + 16
+)
+
+var errOpen = errors.New("commitgcm: message authentication failed")
+
+type commitGCM struct {
+	block  cipher.Block
+	keyLen int
+}
+
+// New returns a key-committing AES-GCM for a 16 byte (AES-128) or 32 byte
+// (AES-256) key. It is safe for concurrent use.
+func New(key []byte) (cipher.AEAD, error) {
+	if len(key) != 16 && len(key) != 32 {
+		return nil, errors.New("commitgcm: key must be 16 or 32 bytes")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &commitGCM{block: block, keyLen: len(key)}, nil
+}
+
+func (*commitGCM) NonceSize() int {
+	return NonceSize
+}
+
+func (*commitGCM) Overhead() int {
+	return Overhead
+}
+
+// derive returns the commitment and the GCM under the message key for
+// nonce.
+func (c *commitGCM) derive(nonce []byte) ([CommitmentSize]byte, cipher.AEAD, error) {
+	var commitment [CommitmentSize]byte
+
+	n := CommitmentSize/aes.BlockSize + c.keyLen/aes.BlockSize
+	in := make([]byte, n*aes.BlockSize)
+	for i := 0; i < n; i++ {
+		b := in[i*aes.BlockSize : (i+1)*aes.BlockSize]
+		copy(b, nonce)
+		binary.BigEndian.PutUint32(b[NonceSize:], uint32(i+1))
+	}
+
+	out := make([]byte, len(in))
+	if err := cipher.EncryptBlocks(c.block, out, in); err != nil {
+		return commitment, nil, err
+	}
+	for i := range out {
+		out[i] ^= in[i]
+	}
+	copy(commitment[:], out)
+
+	block, err := aes.NewCipher(out[CommitmentSize:])
+	if err != nil {
+		return commitment, nil, err
+	}
+	g, err := cipher.NewGCM(block)
+
+	return commitment, g, err
+}
+
+func (c *commitGCM) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != NonceSize {
+		panic("commitgcm: incorrect nonce length given to Seal")
+	}
+
+	commitment, g, err := c.derive(nonce)
+	if err != nil {
+		panic(err)
+	}
+
+	ret, out := alias.SliceForAppend(dst, Overhead+len(plaintext))
+	// The ciphertext lands CommitmentSize bytes after where plaintext[:0]
+	// as dst would put it, so an overlapping plaintext is copied first.
+	if alias.AnyOverlap(out, plaintext) {
+		plaintext = append([]byte(nil), plaintext...)
+	}
+	g.Seal(out[:CommitmentSize], nonce, plaintext, additionalData)
+	copy(out, commitment[:])
+
+	return ret
+}
+
+func (c *commitGCM) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != NonceSize {
+		panic("commitgcm: incorrect nonce length given to Open")
+	}
+	if len(ciphertext) < Overhead {
+		return nil, errOpen
+	}
+
+	commitment, g, err := c.derive(nonce)
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare(commitment[:], ciphertext[:CommitmentSize]) != 1 {
+		return nil, errOpen
+	}
+
+	// Opening into ciphertext[:0] puts the plaintext CommitmentSize bytes
+	// before the GCM ciphertext, so an overlapping one is copied first.
+	ciphertext = ciphertext[CommitmentSize:]
+	if n := len(dst) + len(ciphertext); cap(dst) >= n && alias.AnyOverlap(dst[len(dst):n], ciphertext) {
+		ciphertext = append([]byte(nil), ciphertext...)
+	}
+
+	return g.Open(dst, nonce, ciphertext, additionalData)
+}
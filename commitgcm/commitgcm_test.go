@@ -0,0 +1,268 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commitgcm_test
+
+import (
+	"bytes"
+	gaes "crypto/aes"
+	gcipher "crypto/cipher"
+	"encoding/binary"
+	"encoding/hex"
+	"math/rand"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/commitgcm"
+)
+
+func fromHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+var commitGCMTests = []struct {
+	key, plaintext, ad, result string
+}{
+	{
+		"000102030405060708090a0b0c0d0e0f",
+		"",
+		"",
+		"63bbbff4164554e3951519f70557f09b43877d087f395aac74da31005dfaa0e5f7b18634b90ac67c08e7dde3ec605e50",
+	},
+	{
+		"000102030405060708090a0b0c0d0e0f",
+		hex.EncodeToString([]byte("key-committing AES-GCM")),
+		hex.EncodeToString([]byte("header")),
+		"63bbbff4164554e3951519f70557f09b43877d087f395aac74da31005dfaa0e5b662686be5c7e115b2c7811a3e28041330e1365496be73f9bc743f2f6909a082b03743db0cdc",
+	},
+	{
+		"000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+		"",
+		"",
+		"91f4cd5d8ba21c45b23de3aedee7d5a5405d1a9850b494b698c1a5557b1d893d8c53be7b5a1290b564d7496b8969605f",
+	},
+	{
+		"000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+		hex.EncodeToString([]byte("key-committing AES-GCM")),
+		hex.EncodeToString([]byte("header")),
+		"91f4cd5d8ba21c45b23de3aedee7d5a5405d1a9850b494b698c1a5557b1d893da92f067db216414b0f74d0ebd1b94618c6a56f9704c3fb17c5870c17023510f7becff5b0db56",
+	},
+}
+
+var testNonce = fromHex("cafebabefacedbaddecaf888")
+
+// reference seals with crypto/aes and crypto/cipher, following the
+// package documentation.
+func reference(key, nonce, plaintext, ad []byte) []byte {
+	block, _ := gaes.NewCipher(key)
+	n := 2 + len(key)/16
+	derived := make([]byte, 16*n)
+	for i := 0; i < n; i++ {
+		var x [16]byte
+		copy(x[:], nonce)
+		binary.BigEndian.PutUint32(x[12:], uint32(i+1))
+		block.Encrypt(derived[16*i:], x[:])
+		for j := range x {
+			derived[16*i+j] ^= x[j]
+		}
+	}
+
+	msgBlock, _ := gaes.NewCipher(derived[32:])
+	g, _ := gcipher.NewGCM(msgBlock)
+	return g.Seal(append([]byte(nil), derived[:32]...), nonce, plaintext, ad)
+}
+
+func TestCommitGCM(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	for i, test := range commitGCMTests {
+		aead, err := commitgcm.New(fromHex(test.key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		plaintext, ad := fromHex(test.plaintext), fromHex(test.ad)
+
+		ct := aead.Seal(nil, testNonce, plaintext, ad)
+		if got := hex.EncodeToString(ct); got != test.result {
+			t.Errorf("#%d: got %s, want %s", i, got, test.result)
+			continue
+		}
+		if len(ct) != len(plaintext)+aead.Overhead() {
+			t.Errorf("#%d: overhead %d, want %d", i, len(ct)-len(plaintext), aead.Overhead())
+		}
+
+		pt, err := aead.Open(nil, testNonce, ct, ad)
+		if err != nil {
+			t.Errorf("#%d: Open failed: %v", i, err)
+		} else if !bytes.Equal(pt, plaintext) {
+			t.Errorf("#%d: got %x, want %x", i, pt, plaintext)
+		}
+
+		// A modified commitment, ciphertext or tag fails
+		for _, pos := range []int{0, commitgcm.CommitmentSize - 1, len(ct) - 1} {
+			bad := append([]byte(nil), ct...)
+			bad[pos] ^= 1
+			if _, err := aead.Open(nil, testNonce, bad, ad); err == nil {
+				t.Errorf("#%d: opened with byte %d modified", i, pos)
+			}
+		}
+	}
+}
+
+func TestCommitGCMAgainstReference(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for _, keySize := range []int{16, 32} {
+		key := make([]byte, keySize)
+		r.Read(key)
+		aead, err := commitgcm.New(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, n := range []int{0, 1, 16, 31, 32, 33, 100, 4097} {
+			nonce := make([]byte, commitgcm.NonceSize)
+			msg := make([]byte, n)
+			ad := make([]byte, n%17)
+			r.Read(nonce)
+			r.Read(msg)
+			r.Read(ad)
+
+			want := reference(key, nonce, msg, ad)
+			if got := aead.Seal(nil, nonce, msg, ad); !bytes.Equal(got, want) {
+				t.Errorf("key %d, %d bytes: differs from the reference", keySize, n)
+			}
+
+			// In place, both ways
+			buf := append(make([]byte, 0, n+aead.Overhead()), msg...)
+			ct := aead.Seal(buf[:0], nonce, buf, ad)
+			if !bytes.Equal(ct, want) {
+				t.Errorf("key %d, %d bytes: in place Seal differs", keySize, n)
+			}
+			if pt, err := aead.Open(ct[:0], nonce, ct, ad); err != nil || !bytes.Equal(pt, msg) {
+				t.Errorf("key %d, %d bytes: in place Open failed: %v", keySize, n, err)
+			}
+		}
+	}
+}
+
+// Only the sealing key opens a message: any other key derives another
+// commitment, even where plain GCM could be made to accept
+func TestCommitGCMOtherKeys(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	key := make([]byte, 32)
+	aead, err := commitgcm.New(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ct := aead.Seal(nil, testNonce, []byte("for one recipient only"), nil)
+
+	for i := 0; i < 256; i++ {
+		other := append([]byte(nil), key...)
+		other[i%32] ^= byte(1 << (i % 8))
+		a, err := commitgcm.New(other)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := a.Open(nil, testNonce, ct, nil); err == nil {
+			t.Fatalf("opened under key %x", other)
+		}
+	}
+
+	short, err := commitgcm.New(key[:16])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := short.Open(nil, testNonce, ct, nil); err == nil {
+		t.Error("opened under an AES-128 key")
+	}
+	if _, err := aead.Open(nil, testNonce, ct[:commitgcm.Overhead-1], nil); err == nil {
+		t.Error("opened a truncated message")
+	}
+
+	for _, size := range []int{0, 24, 64} {
+		if _, err := commitgcm.New(make([]byte, size)); err == nil {
+			t.Errorf("accepted a %d byte key", size)
+		}
+	}
+}
+
+func benchmarkSeal(b *testing.B, keySize, size int) {
+	if !aes.IsSupported() {
+		b.Skip("AES-NI not supported")
+	}
+
+	aead, err := commitgcm.New(make([]byte, keySize))
+	if err != nil {
+		b.Fatal(err)
+	}
+	nonce := make([]byte, commitgcm.NonceSize)
+	msg := make([]byte, size)
+	out := make([]byte, 0, size+aead.Overhead())
+
+	b.SetBytes(int64(size))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		aead.Seal(out, nonce, msg, nil)
+	}
+}
+
+func benchmarkOpen(b *testing.B, keySize, size int) {
+	if !aes.IsSupported() {
+		b.Skip("AES-NI not supported")
+	}
+
+	aead, err := commitgcm.New(make([]byte, keySize))
+	if err != nil {
+		b.Fatal(err)
+	}
+	nonce := make([]byte, commitgcm.NonceSize)
+	ct := aead.Seal(nil, nonce, make([]byte, size), nil)
+	out := make([]byte, 0, size)
+
+	b.SetBytes(int64(size))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := aead.Open(out, nonce, ct, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCommitGCM128Seal1K(b *testing.B) { benchmarkSeal(b, 16, 1024) }
+func BenchmarkCommitGCM128Seal8K(b *testing.B) { benchmarkSeal(b, 16, 8192) }
+func BenchmarkCommitGCM128Open1K(b *testing.B) { benchmarkOpen(b, 16, 1024) }
+func BenchmarkCommitGCM256Seal1K(b *testing.B) { benchmarkSeal(b, 32, 1024) }
+func BenchmarkCommitGCM256Seal8K(b *testing.B) { benchmarkSeal(b, 32, 8192) }
+func BenchmarkCommitGCM256Open1K(b *testing.B) { benchmarkOpen(b, 32, 1024) }
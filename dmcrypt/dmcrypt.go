@@ -0,0 +1,333 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package dmcrypt encrypts sectors as Linux dm-crypt does for a cipher
+// spec such as "aes-xts-plain64", "aes-cbc-essiv:sha256" or
+// "aes-cbc-benbi": it parses the spec and derives the IV of each sector
+// from its number, so callers do not set IVs themselves.
+//
+// A spec is cipher[:keycount]-chainmode-ivmode[:ivopts], as dmsetup
+// takes it and LUKS stores it. The cipher is aes, the chain modes are
+// xts, cbc and ecb, which takes no IV, and the IV modes are
+//
+//	plain      the sector number, truncated to 32 bits, little-endian
+//	plain64    the sector number, little-endian
+//	plain64be  the sector number, big-endian, at the end of the IV
+//	essiv:hash the sector number, little-endian, encrypted with AES
+//	           under the hash of the key
+//	benbi      the number of the first 16 byte block of the sector,
+//	           counting from 1, big-endian, at the end of the IV
+//	null       zeros
+//
+// As in dm-crypt, "aes" and "aes-plain" mean "aes-cbc-plain". Sector
+// numbers count 512 byte sectors whatever the sector size, as dm-crypt
+// without the iv_large_sectors option: the IV of a 4096 byte sector is
+// derived from the number of its first 512 bytes.
+package dmcrypt
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cipher"
+)
+
+var (
+	errSpec   = errors.New("dmcrypt: invalid cipher spec")
+	errLength = errors.New("dmcrypt: length not a multiple of the sector size")
+)
+
+// hashes are the ESSIV hashes the package supports.
+var hashes = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha224": sha256.New224,
+	"sha256": sha256.New,
+	"sha384": sha512.New384,
+	"sha512": sha512.New,
+}
+
+// A Spec is a parsed cipher spec.
+type Spec struct {
+	Cipher    string // "aes"
+	KeyCount  int    // 1
+	ChainMode string // "xts", "cbc" or "ecb"
+	IVMode    string // "plain", "plain64", ...; empty for ecb
+	IVOpts    string // the hash of essiv
+}
+
+// ParseSpec parses a dm-crypt cipher spec.
+func ParseSpec(s string) (Spec, error) {
+	if strings.HasPrefix(s, "capi:") {
+		return Spec{}, errors.New("dmcrypt: crypto API specs not supported")
+	}
+
+	parts := strings.SplitN(s, "-", 3)
+	spec := Spec{Cipher: parts[0], KeyCount: 1}
+	if i := strings.IndexByte(spec.Cipher, ':'); i >= 0 {
+		n, err := strconv.Atoi(spec.Cipher[i+1:])
+		if err != nil || n < 1 {
+			return Spec{}, errSpec
+		}
+		spec.Cipher, spec.KeyCount = spec.Cipher[:i], n
+	}
+	if len(parts) > 1 {
+		spec.ChainMode = parts[1]
+	}
+	if len(parts) > 2 {
+		spec.IVMode = parts[2]
+		if i := strings.IndexByte(spec.IVMode, ':'); i >= 0 {
+			spec.IVMode, spec.IVOpts = spec.IVMode[:i], spec.IVMode[i+1:]
+		}
+	}
+
+	// The defaults of the old dm-crypt syntax
+	if spec.ChainMode == "" || spec.ChainMode == "plain" && spec.IVMode == "" {
+		spec.ChainMode, spec.IVMode = "cbc", "plain"
+	}
+
+	if spec.Cipher != "aes" {
+		return Spec{}, errors.New("dmcrypt: unsupported cipher " + spec.Cipher)
+	}
+	if spec.KeyCount != 1 {
+		return Spec{}, errors.New("dmcrypt: multiple keys not supported")
+	}
+	switch spec.ChainMode {
+	case "ecb":
+		if spec.IVMode != "" {
+			return Spec{}, errors.New("dmcrypt: ecb takes no IV")
+		}
+		return spec, nil
+	case "xts", "cbc":
+	default:
+		return Spec{}, errors.New("dmcrypt: unsupported chain mode " + spec.ChainMode)
+	}
+
+	switch spec.IVMode {
+	case "plain", "plain64", "plain64be", "benbi", "null":
+		if spec.IVOpts != "" {
+			return Spec{}, errSpec
+		}
+	case "essiv":
+		if _, ok := hashes[spec.IVOpts]; !ok {
+			return Spec{}, errors.New("dmcrypt: unsupported ESSIV hash " + spec.IVOpts)
+		}
+	case "":
+		return Spec{}, errors.New("dmcrypt: IV mode required")
+	default:
+		return Spec{}, errors.New("dmcrypt: unsupported IV mode " + spec.IVMode)
+	}
+
+	return spec, nil
+}
+
+// String returns the spec in the form ParseSpec takes.
+func (s Spec) String() string {
+	str := s.Cipher
+	if s.KeyCount > 1 {
+		str += ":" + strconv.Itoa(s.KeyCount)
+	}
+	str += "-" + s.ChainMode
+	if s.IVMode != "" {
+		str += "-" + s.IVMode
+	}
+	if s.IVOpts != "" {
+		str += ":" + s.IVOpts
+	}
+
+	return str
+}
+
+// A SectorCipher encrypts and decrypts sectors as dm-crypt does for a
+// cipher spec. It is safe for concurrent use.
+type SectorCipher struct {
+	spec       Spec
+	sectorSize int
+
+	mu    sync.Mutex
+	block cipher.Block
+	mode  cipher.BlockMode // nil for ecb
+	essiv cipher.Block
+	iv    [16]byte
+	buf   []byte
+}
+
+// NewSectorCipher returns a SectorCipher for the spec with the key and
+// sectors of sectorSize bytes, a power of two from 512 to 4096. xts takes
+// 32 or 64 byte keys and cbc and ecb 16, 24 or 32 byte keys; the ESSIV
+// hash must be 16, 24 or 32 bytes long.
+func NewSectorCipher(spec string, key []byte, sectorSize int) (*SectorCipher, error) {
+	sp, err := ParseSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	if sectorSize < 512 || sectorSize > 4096 || sectorSize&(sectorSize-1) != 0 {
+		return nil, errors.New("dmcrypt: invalid sector size")
+	}
+
+	sizes := []int{16, 24, 32}
+	if sp.ChainMode == "xts" {
+		sizes = []int{32, 64}
+	}
+	ok := false
+	for _, n := range sizes {
+		ok = ok || len(key) == n
+	}
+	if !ok {
+		return nil, errors.New("dmcrypt: invalid key size for " + spec)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	c := &SectorCipher{spec: sp, sectorSize: sectorSize, block: block, buf: make([]byte, sectorSize)}
+	switch sp.ChainMode {
+	case "xts":
+		c.mode = cipher.NewXTSEncryptor(block)
+	case "cbc":
+		c.mode = cipher.NewCBCEncrypter(block, c.iv[:])
+	}
+
+	if sp.IVMode == "essiv" {
+		d := hashes[sp.IVOpts]()
+		d.Write(key)
+		if c.essiv, err = aes.NewCipher(d.Sum(nil)); err != nil {
+			return nil, errors.New("dmcrypt: ESSIV hash not an AES key size")
+		}
+	}
+
+	return c, nil
+}
+
+// Spec returns the parsed cipher spec of c.
+func (c *SectorCipher) Spec() Spec {
+	return c.spec
+}
+
+// SectorSize returns the sector size of c.
+func (c *SectorCipher) SectorSize() int {
+	return c.sectorSize
+}
+
+// IV writes to iv, 16 bytes, the IV of the sector numbered sector in 512
+// byte units. It does nothing for ecb, which takes no IV.
+func (c *SectorCipher) IV(iv []byte, sector uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.setIV(iv, sector)
+}
+
+func (c *SectorCipher) setIV(iv []byte, sector uint64) error {
+	if c.spec.ChainMode == "ecb" {
+		return nil
+	}
+	if len(iv) != 16 {
+		return errors.New("dmcrypt: invalid IV size")
+	}
+
+	for i := range iv {
+		iv[i] = 0
+	}
+	switch c.spec.IVMode {
+	case "plain":
+		binary.LittleEndian.PutUint32(iv, uint32(sector))
+	case "plain64":
+		binary.LittleEndian.PutUint64(iv, sector)
+	case "plain64be":
+		binary.BigEndian.PutUint64(iv[len(iv)-8:], sector)
+	case "essiv":
+		binary.LittleEndian.PutUint64(iv, sector)
+		return cipher.EncryptBlock(c.essiv, iv, iv)
+	case "benbi":
+		// 512 byte sectors of 32 AES blocks
+		binary.BigEndian.PutUint64(iv[len(iv)-8:], sector<<5+1)
+	}
+
+	return nil
+}
+
+// Encrypt encrypts src, whole sectors, into dst. sector is the number
+// of the first in 512 byte units. dst and src must overlap entirely or
+// not at all.
+func (c *SectorCipher) Encrypt(dst, src []byte, sector uint64) error {
+	return c.crypt(dst, src, sector, true)
+}
+
+// Decrypt decrypts src, whole sectors, into dst. sector is the number
+// of the first in 512 byte units. dst and src must overlap entirely or
+// not at all.
+func (c *SectorCipher) Decrypt(dst, src []byte, sector uint64) error {
+	return c.crypt(dst, src, sector, false)
+}
+
+func (c *SectorCipher) crypt(dst, src []byte, sector uint64, encrypt bool) error {
+	if len(src)%c.sectorSize != 0 {
+		return errLength
+	}
+	if len(dst) < len(src) {
+		return errors.New("dmcrypt: destination buffer too small")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := 0; i < len(src); i += c.sectorSize {
+		in, out := src[i:i+c.sectorSize], dst[i:i+c.sectorSize]
+		if &in[0] == &out[0] {
+			copy(c.buf, in)
+			in = c.buf
+		}
+
+		var err error
+		switch {
+		case c.mode == nil && encrypt:
+			err = cipher.EncryptBlocks(c.block, out, in)
+		case c.mode == nil:
+			err = cipher.DecryptBlocks(c.block, out, in)
+		default:
+			if err := c.setIV(c.iv[:], sector); err != nil {
+				return err
+			}
+			c.mode.SetIV(c.iv[:])
+			if encrypt {
+				err = c.mode.Encrypt(out, in)
+			} else {
+				err = c.mode.Decrypt(out, in)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		sector += uint64(c.sectorSize / 512)
+	}
+
+	return nil
+}
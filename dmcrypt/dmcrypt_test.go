@@ -0,0 +1,391 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package dmcrypt_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	goaes "crypto/aes"
+	gocipher "crypto/cipher"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cavp"
+	"github.com/surendarchandra/crypto/dmcrypt"
+)
+
+func TestParseSpec(t *testing.T) {
+	for _, c := range []struct {
+		in   string
+		spec dmcrypt.Spec
+		out  string
+	}{
+		{"aes-xts-plain64", dmcrypt.Spec{"aes", 1, "xts", "plain64", ""}, "aes-xts-plain64"},
+		{"aes-cbc-essiv:sha256", dmcrypt.Spec{"aes", 1, "cbc", "essiv", "sha256"}, "aes-cbc-essiv:sha256"},
+		{"aes-cbc-benbi", dmcrypt.Spec{"aes", 1, "cbc", "benbi", ""}, "aes-cbc-benbi"},
+		{"aes:1-xts-plain64be", dmcrypt.Spec{"aes", 1, "xts", "plain64be", ""}, "aes-xts-plain64be"},
+		{"aes-ecb", dmcrypt.Spec{"aes", 1, "ecb", "", ""}, "aes-ecb"},
+		{"aes", dmcrypt.Spec{"aes", 1, "cbc", "plain", ""}, "aes-cbc-plain"},
+		{"aes-plain", dmcrypt.Spec{"aes", 1, "cbc", "plain", ""}, "aes-cbc-plain"},
+	} {
+		spec, err := dmcrypt.ParseSpec(c.in)
+		if err != nil || spec != c.spec || spec.String() != c.out {
+			t.Errorf("ParseSpec(%q) = %+v, %v", c.in, spec, err)
+		}
+	}
+
+	for _, in := range []string{
+		"", "twofish-xts-plain64", "aes:2-cbc-lmk", "aes:x-xts-plain64", "aes-ctr-plain64",
+		"aes-cbc", "aes-cbc-lmk", "aes-cbc-essiv", "aes-cbc-essiv:md5", "aes-cbc-plain64:sha256",
+		"aes-ecb-plain", "capi:xts(aes)-plain64",
+	} {
+		if _, err := dmcrypt.ParseSpec(in); err == nil {
+			t.Errorf("ParseSpec accepted %q", in)
+		}
+	}
+}
+
+// TestSectorCipherIEEE1619 checks aes-xts-plain64 against the IEEE 1619
+// vectors with 512 byte data units, whose tweak is the sector number.
+func TestSectorCipherIEEE1619(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	count := 0
+	for _, name := range []string{"XTSGenAES128.rsp", "XTSGenAES256.rsp"} {
+		r, err := os.Open(filepath.Join("..", "cavp", "testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := cavp.Parse(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, sec := range f.Sections {
+			if !sec.Has("ENCRYPT") {
+				continue
+			}
+			for _, rec := range sec.Records {
+				if n, _ := rec.Get("DataUnitLen"); n != "4096" {
+					continue
+				}
+				key, _ := rec.Get("Key")
+				tweak, _ := rec.Get("i")
+				pt, _ := rec.Get("PT")
+				ct, _ := rec.Get("CT")
+				k, _ := hex.DecodeString(key)
+				i, _ := hex.DecodeString(tweak)
+				plain, _ := hex.DecodeString(pt)
+				want, _ := hex.DecodeString(ct)
+				if !bytes.Equal(i[8:], make([]byte, 8)) {
+					continue
+				}
+				sector := binary.LittleEndian.Uint64(i)
+
+				c, err := dmcrypt.NewSectorCipher("aes-xts-plain64", k, 512)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got := make([]byte, len(plain))
+				if err := c.Encrypt(got, plain, sector); err != nil || !bytes.Equal(got, want) {
+					t.Errorf("%s sector %#x: Encrypt returned %v, ciphertext differs: %t", name, sector, err, !bytes.Equal(got, want))
+				}
+				if err := c.Decrypt(got, got, sector); err != nil || !bytes.Equal(got, plain) {
+					t.Errorf("%s sector %#x: Decrypt returned %v, plaintext differs: %t", name, sector, err, !bytes.Equal(got, plain))
+				}
+
+				// plain keeps the low 32 bits of the sector number
+				c, err = dmcrypt.NewSectorCipher("aes-xts-plain", k, 512)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := c.Encrypt(got, plain, sector); err != nil || bytes.Equal(got, want) != (sector < 1<<32) {
+					t.Errorf("%s sector %#x: plain returned %v, ciphertext matches plain64: %t", name, sector, err, bytes.Equal(got, want))
+				}
+				count++
+			}
+		}
+	}
+	if count < 9 {
+		t.Fatalf("ran %d vectors", count)
+	}
+}
+
+func TestSectorCipherIV(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	salt := sha256.Sum256(key)
+	essiv, _ := goaes.NewCipher(salt[:])
+	essivIV := func(sector uint64) string {
+		var iv [16]byte
+		binary.LittleEndian.PutUint64(iv[:], sector)
+		essiv.Encrypt(iv[:], iv[:])
+		return hex.EncodeToString(iv[:])
+	}
+
+	for _, c := range []struct {
+		spec   string
+		sector uint64
+		iv     string
+	}{
+		{"aes-cbc-plain", 0x123456789a, "9a785634000000000000000000000000"},
+		{"aes-cbc-plain64", 0x123456789a, "9a785634120000000000000000000000"},
+		{"aes-cbc-plain64be", 0x123456789a, "0000000000000000000000123456789a"},
+		{"aes-cbc-benbi", 0, "00000000000000000000000000000001"},
+		{"aes-cbc-benbi", 1, "00000000000000000000000000000021"},
+		{"aes-cbc-benbi", 0x123456789a, "0000000000000000000002468acf1341"},
+		{"aes-cbc-null", 0x123456789a, "00000000000000000000000000000000"},
+		{"aes-cbc-essiv:sha256", 0, essivIV(0)},
+		{"aes-cbc-essiv:sha256", 0x123456789a, essivIV(0x123456789a)},
+	} {
+		sc, err := dmcrypt.NewSectorCipher(c.spec, key, 512)
+		if err != nil {
+			t.Fatal(err)
+		}
+		iv := make([]byte, 16)
+		if err := sc.IV(iv, c.sector); err != nil || hex.EncodeToString(iv) != c.iv {
+			t.Errorf("%s sector %#x: IV %x, %v, want %s", c.spec, c.sector, iv, err, c.iv)
+		}
+	}
+}
+
+// TestSectorCipherCBC compares the cbc specs with crypto/cipher's CBC
+// under the IVs of dm-crypt, for 512 and 4096 byte sectors, whose IVs
+// count 512 byte sectors.
+func TestSectorCipherCBC(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for _, keySize := range []int{16, 24, 32} {
+		key := make([]byte, keySize)
+		r.Read(key)
+		block, _ := goaes.NewCipher(key)
+
+		for _, spec := range []string{"aes-cbc-plain", "aes-cbc-plain64", "aes-cbc-plain64be", "aes-cbc-essiv:sha256", "aes-cbc-benbi"} {
+			for _, sectorSize := range []int{512, 4096} {
+				sc, err := dmcrypt.NewSectorCipher(spec, key, sectorSize)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if sc.SectorSize() != sectorSize || sc.Spec().String() != spec {
+					t.Fatalf("%s: sector size %d, spec %v", spec, sc.SectorSize(), sc.Spec())
+				}
+
+				plain := make([]byte, 3*sectorSize)
+				r.Read(plain)
+				sector := uint64(1<<32 - 8)
+				want := make([]byte, len(plain))
+				for i := 0; i < len(plain); i += sectorSize {
+					iv := make([]byte, 16)
+					sc.IV(iv, sector+uint64(i/512))
+					gocipher.NewCBCEncrypter(block, iv).CryptBlocks(want[i:i+sectorSize], plain[i:i+sectorSize])
+				}
+
+				got := append([]byte(nil), plain...)
+				if err := sc.Encrypt(got, got, sector); err != nil || !bytes.Equal(got, want) {
+					t.Errorf("%s/%d: Encrypt returned %v, ciphertext differs: %t", spec, sectorSize, err, !bytes.Equal(got, want))
+				}
+				dec := make([]byte, len(got))
+				if err := sc.Decrypt(dec, got, sector); err != nil || !bytes.Equal(dec, plain) {
+					t.Errorf("%s/%d: Decrypt returned %v, plaintext differs: %t", spec, sectorSize, err, !bytes.Equal(dec, plain))
+				}
+			}
+		}
+	}
+}
+
+// readImage returns the gunzipped testdata file, or nil if it is missing.
+func readImage(t *testing.T, file string) []byte {
+	t.Helper()
+
+	f, err := os.Open(filepath.Join("testdata", file))
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return img
+}
+
+// checkImage compares the encryption of zeros under spec from IV sector
+// ivOffset with want.
+func checkImage(t *testing.T, file, spec string, sectorSize int, ivOffset uint64, want []byte) {
+	t.Helper()
+
+	key, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	sc, err := dmcrypt.NewSectorCipher(spec, key, sectorSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make([]byte, len(want))
+	if err := sc.Encrypt(got, make([]byte, len(want)), ivOffset); err != nil || !bytes.Equal(got, want) {
+		t.Errorf("%s: Encrypt returned %v, ciphertext differs: %t", file, err, !bytes.Equal(got, want))
+	}
+}
+
+// TestSectorCipherCryptsetup compares the ciphertext of libcryptsetup's
+// userspace encryption, written by testdata/cryptsetup.sh, with Encrypt.
+func TestSectorCipherCryptsetup(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	for _, c := range []struct {
+		file string
+		spec string
+	}{
+		{"cryptsetup-aes-cbc-essiv-sha256-512.bin.gz", "aes-cbc-essiv:sha256"},
+		{"cryptsetup-aes-cbc-benbi-512.bin.gz", "aes-cbc-benbi"},
+		{"cryptsetup-aes-cbc-plain64be-512.bin.gz", "aes-cbc-plain64be"},
+	} {
+		want := readImage(t, c.file)
+		if want == nil {
+			t.Fatalf("%s missing; run testdata/cryptsetup.sh to write it", c.file)
+		}
+		checkImage(t, c.file, c.spec, 512, 0, want)
+	}
+}
+
+// TestSectorCipherDMSetup compares the ciphertext of the kernel's
+// dm-crypt, written by testdata/dmsetup.sh, with Encrypt. It covers the
+// IV offsets and 4096 byte sectors libcryptsetup's userspace encryption
+// cannot, but the images need root and the device mapper to write, so the
+// test skips when they are missing.
+func TestSectorCipherDMSetup(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	found := false
+	for _, c := range []struct {
+		file       string
+		spec       string
+		sectorSize int
+	}{
+		{"dmsetup-aes-cbc-essiv-sha256-512.bin.gz", "aes-cbc-essiv:sha256", 512},
+		{"dmsetup-aes-cbc-essiv-sha256-4096.bin.gz", "aes-cbc-essiv:sha256", 4096},
+		{"dmsetup-aes-cbc-benbi-512.bin.gz", "aes-cbc-benbi", 512},
+		{"dmsetup-aes-cbc-plain64be-512.bin.gz", "aes-cbc-plain64be", 512},
+		{"dmsetup-aes-cbc-plain64be-4096.bin.gz", "aes-cbc-plain64be", 4096},
+	} {
+		if want := readImage(t, c.file); want != nil {
+			found = true
+			checkImage(t, c.file, c.spec, c.sectorSize, 1<<32-8, want)
+		}
+	}
+	if !found {
+		t.Skip("no dm-crypt images; run testdata/dmsetup.sh to write them")
+	}
+}
+
+func TestSectorCipherECB(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	key := make([]byte, 16)
+	block, _ := goaes.NewCipher(key)
+	plain := make([]byte, 1024)
+	rand.New(rand.NewSource(2)).Read(plain)
+	want := make([]byte, len(plain))
+	for i := 0; i < len(plain); i += 16 {
+		block.Encrypt(want[i:], plain[i:])
+	}
+
+	sc, err := dmcrypt.NewSectorCipher("aes-ecb", key, 512)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make([]byte, len(plain))
+	if err := sc.Encrypt(got, plain, 7); err != nil || !bytes.Equal(got, want) {
+		t.Errorf("Encrypt returned %v, ciphertext differs: %t", err, !bytes.Equal(got, want))
+	}
+	if err := sc.Decrypt(got, got, 7); err != nil || !bytes.Equal(got, plain) {
+		t.Errorf("Decrypt returned %v, plaintext differs: %t", err, !bytes.Equal(got, plain))
+	}
+}
+
+func TestSectorCipherErrors(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	for _, c := range []struct {
+		spec       string
+		keySize    int
+		sectorSize int
+	}{
+		{"aes-xts-plain64", 16, 512},
+		{"aes-xts-plain64", 48, 512},
+		{"aes-cbc-plain64", 64, 512},
+		{"aes-cbc-essiv:sha1", 16, 512},
+		{"aes-cbc-plain64", 16, 256},
+		{"aes-cbc-plain64", 16, 1000},
+		{"aes-cbc-plain64", 16, 8192},
+		{"aes-cbc-lmk", 16, 512},
+	} {
+		if _, err := dmcrypt.NewSectorCipher(c.spec, make([]byte, c.keySize), c.sectorSize); err == nil {
+			t.Errorf("NewSectorCipher accepted %s with a %d byte key and %d byte sectors", c.spec, c.keySize, c.sectorSize)
+		}
+	}
+
+	sc, err := dmcrypt.NewSectorCipher("aes-xts-plain64", make([]byte, 32), 512)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 1024)
+	if err := sc.Encrypt(buf, buf[:1000], 0); err == nil {
+		t.Error("Encrypt accepted a partial sector")
+	}
+	if err := sc.Decrypt(buf[:512], buf, 0); err == nil {
+		t.Error("Decrypt accepted a short destination")
+	}
+}
@@ -0,0 +1,92 @@
+/*
+ * Writes the cryptsetup-*.bin images that TestSectorCipherCryptsetup
+ * checks: 8 KiB of zeros encrypted by libcryptsetup under each spec with
+ * the key 000102...1f, 512 byte sectors and no IV offset. cryptsetup.sh
+ * builds and runs it.
+ *
+ * The data is encrypted by an offline LUKS2 encryption against a detached
+ * header, which runs libcryptsetup's own userspace IV generators and
+ * cipher rather than the kernel's dm-crypt, so the device mapper is not
+ * needed.
+ */
+#include <errno.h>
+#include <fcntl.h>
+#include <stdio.h>
+#include <stdlib.h>
+#include <string.h>
+#include <unistd.h>
+
+#include <libcryptsetup.h>
+
+#define DATA_SIZE 8192
+
+static void check(int r, const char *what)
+{
+	if (r < 0) {
+		fprintf(stderr, "%s: %s\n", what, strerror(-r));
+		exit(1);
+	}
+}
+
+/* encrypt writes image, zeros encrypted under aes-mode */
+static void encrypt(const char *image, const char *mode)
+{
+	char hdr[] = "/tmp/cryptsetup-hdr-XXXXXX", key[32];
+	const char *pass = "encrypt";
+	struct crypt_pbkdf_type pbkdf = {
+		.type = CRYPT_KDF_PBKDF2, .hash = "sha256", .iterations = 1000,
+		.flags = CRYPT_PBKDF_NO_BENCHMARK,
+	};
+	struct crypt_params_luks2 params = {
+		.pbkdf = &pbkdf, .sector_size = 512, .data_device = image,
+	};
+	struct crypt_params_reencrypt reenc = {
+		.mode = CRYPT_REENCRYPT_ENCRYPT, .direction = CRYPT_REENCRYPT_FORWARD,
+		.resilience = "none", .luks2 = &params,
+		.flags = CRYPT_REENCRYPT_INITIALIZE_ONLY,
+	};
+	struct crypt_device *cd;
+	int hfd, fd, slot;
+
+	for (int i = 0; i < (int)sizeof(key); i++)
+		key[i] = i;
+
+	hfd = mkstemp(hdr);
+	if (hfd < 0)
+		check(-errno, "mkstemp");
+	check(ftruncate(hfd, 16 << 20) ? -errno : 0, "ftruncate");
+	fd = open(image, O_RDWR | O_CREAT | O_TRUNC, 0644);
+	if (fd < 0)
+		check(-errno, image);
+	check(ftruncate(fd, DATA_SIZE) ? -errno : 0, "ftruncate");
+
+	check(crypt_init(&cd, hdr), "crypt_init");
+	check(crypt_set_pbkdf_type(cd, &pbkdf), "crypt_set_pbkdf_type");
+	check(crypt_format(cd, CRYPT_LUKS2, "aes", mode, NULL, key, sizeof(key), &params), "crypt_format");
+	slot = crypt_keyslot_add_by_volume_key(cd, CRYPT_ANY_SLOT, key, sizeof(key), pass, strlen(pass));
+	check(slot, "crypt_keyslot_add_by_volume_key");
+	check(crypt_reencrypt_init_by_passphrase(cd, NULL, pass, strlen(pass), CRYPT_ANY_SLOT,
+						 slot, "aes", mode, &reenc), "crypt_reencrypt_init");
+	crypt_free(cd);
+
+	check(crypt_init_data_device(&cd, hdr, image), "crypt_init_data_device");
+	check(crypt_load(cd, CRYPT_LUKS2, NULL), "crypt_load");
+	reenc.flags = 0;
+	check(crypt_reencrypt_init_by_passphrase(cd, NULL, pass, strlen(pass), CRYPT_ANY_SLOT,
+						 slot, NULL, NULL, &reenc), "crypt_reencrypt_init");
+	check(crypt_reencrypt_run(cd, NULL, NULL), "crypt_reencrypt_run");
+	crypt_free(cd);
+
+	close(fd);
+	close(hfd);
+	unlink(hdr);
+}
+
+int main(void)
+{
+	encrypt("cryptsetup-aes-cbc-essiv-sha256-512.bin", "cbc-essiv:sha256");
+	encrypt("cryptsetup-aes-cbc-benbi-512.bin", "cbc-benbi");
+	encrypt("cryptsetup-aes-cbc-plain64be-512.bin", "cbc-plain64be");
+
+	return 0;
+}
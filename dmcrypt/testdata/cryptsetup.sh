@@ -0,0 +1,15 @@
+#!/bin/sh
+# Writes the cryptsetup-*.bin.gz images that TestSectorCipherCryptsetup
+# checks: libcryptsetup's userspace encryption under each spec. Neither
+# root nor the device mapper is needed, only libcryptsetup 2.4 or later
+# and its headers. Run from dmcrypt/testdata:
+#
+#	sh cryptsetup.sh
+set -e
+
+gen=$(mktemp)
+trap 'rm -f "$gen"' EXIT
+cc -o "$gen" cryptsetup.c -lcryptsetup
+"$gen"
+gzip -9 -n -f cryptsetup-aes-cbc-essiv-sha256-512.bin cryptsetup-aes-cbc-benbi-512.bin \
+	cryptsetup-aes-cbc-plain64be-512.bin
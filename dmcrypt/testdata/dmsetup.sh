@@ -0,0 +1,32 @@
+#!/bin/sh
+# Writes the dmsetup-*.bin.gz images that TestSectorCipherDMSetup checks
+# when present: 64 KiB of zeros written through the kernel's dm-crypt
+# under each spec, starting at IV sector 2^32 - 8 so the high bits of the
+# sector number show. Run from dmcrypt/testdata as root on Linux:
+#
+#	sudo sh dmsetup.sh
+set -e
+
+key=000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f
+ivoffset=4294967288
+
+# image spec sector_size
+write() {
+	truncate -s 64K "$1"
+	loop=$(losetup --find --show "$1")
+	opts=
+	if [ "$3" != 512 ]; then
+		opts="1 sector_size:$3"
+	fi
+	dmsetup create dmcrypt-fixture --table "0 128 crypt $2 $key $ivoffset $loop 0 $opts"
+	dd if=/dev/zero of=/dev/mapper/dmcrypt-fixture bs=65536 count=1 oflag=direct
+	dmsetup remove dmcrypt-fixture
+	losetup --detach "$loop"
+	gzip -9 -f "$1"
+}
+
+write dmsetup-aes-cbc-essiv-sha256-512.bin aes-cbc-essiv:sha256 512
+write dmsetup-aes-cbc-essiv-sha256-4096.bin aes-cbc-essiv:sha256 4096
+write dmsetup-aes-cbc-benbi-512.bin aes-cbc-benbi 512
+write dmsetup-aes-cbc-plain64be-512.bin aes-cbc-plain64be 512
+write dmsetup-aes-cbc-plain64be-4096.bin aes-cbc-plain64be 4096
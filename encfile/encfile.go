@@ -0,0 +1,536 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package encfile implements a random-access encrypted file on top of a
+// file-like Backend: File is an io.ReaderAt and io.WriterAt and can be
+// truncated, and a read or write only decrypts and encrypts the chunks it
+// touches.
+//
+// The file starts with a header:
+//
+//	magic "ENCF" | version 1 | chunk size (uint32) | salt (16 bytes) |
+//	reserved write counter (uint64) | key ID length (1 byte) | key ID
+//
+// followed by the chunks. Each chunk holds chunk size bytes of plaintext,
+// but for the last, which is shorter (and empty only in an empty file),
+// and is stored as
+//
+//	write counter (uint64) | AES-GCM ciphertext | tag (16 bytes)
+//
+// The chunk key is HKDF-SHA-256 of the key with the salt, so each file
+// has its own key. The nonce of a chunk is its index (uint32) followed by
+// the write counter, which grows with every chunk written to the file and
+// is reserved in the header a window at a time, so rewriting a chunk, even
+// after truncating and growing the file, never repeats a nonce. The
+// additional data marks the last chunk, so cutting off whole chunks is
+// detected. All integers are big-endian.
+//
+// Chunks are authenticated one by one: replacing a chunk with an older
+// version of the same chunk (a rollback) is not detected. The reserved
+// write counter in the header is not authenticated either: Open takes it
+// as found, so anyone who can write the file can lower it, or roll the
+// whole file back, and make later writes repeat nonces under the file's
+// key, which leaks the XOR of the old and new plaintexts and allows
+// forging chunks. The file must only be opened for writing from storage
+// that is trusted not to be modified or rolled back. Writes are not
+// atomic; a crash in the middle of one can leave the file unreadable.
+package encfile
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cipher"
+	"github.com/surendarchandra/crypto/internal/hkdf"
+)
+
+const (
+	// DefaultChunkSize is the plaintext size of a chunk suited to most
+	// files.
+	DefaultChunkSize = 4096
+
+	// MinChunkSize and MaxChunkSize bound the chunk size.
+	MinChunkSize = 512
+	MaxChunkSize = 1 << 24
+
+	// ChunkOverhead is the size added to each chunk: the write counter
+	// and the tag.
+	ChunkOverhead = 8 + 16
+
+	// MaxKeyIDSize is the longest key ID the header holds.
+	MaxKeyIDSize = 255
+
+	version = 1
+
+	// fixedHeaderSize is the header up to the key ID
+	fixedHeaderSize = 4 + 1 + 4 + 16 + 8 + 1
+
+	// counterOffset is the offset of the reserved write counter
+	counterOffset = 4 + 1 + 4 + 16
+
+	// counterWindow is the number of write counters reserved at once
+	counterWindow = 1 << 16
+
+	// maxChunks is the number of chunk indexes
+	maxChunks = 1 << 32
+)
+
+var (
+	magic = []byte("ENCF")
+
+	errOpen    = errors.New("encfile: message authentication failed")
+	errFormat  = errors.New("encfile: not an encrypted file")
+	errOffset  = errors.New("encfile: negative offset")
+	errTooLong = errors.New("encfile: file too large")
+)
+
+// A Backend stores an encrypted file; *os.File is one.
+type Backend interface {
+	io.ReaderAt
+	io.WriterAt
+	Truncate(size int64) error
+	Stat() (os.FileInfo, error)
+	Sync() error
+}
+
+// A File is an encrypted file. It is safe for concurrent use.
+type File struct {
+	mu         sync.Mutex
+	b          Backend
+	aead       cipher.AEAD
+	keyID      []byte
+	chunkSize  int
+	headerSize int64
+	size       int64
+
+	// Write counters run from next up to, not including, reserved,
+	// which the header holds
+	next, reserved uint64
+
+	plain, sealed []byte
+	nonce         [12]byte
+}
+
+// Create formats b, which is truncated, as an empty encrypted file for a
+// 16 or 32 byte key, which key ID names for Open, with chunks of chunkSize
+// plaintext bytes, MinChunkSize to MaxChunkSize.
+func Create(b Backend, key, keyID []byte, chunkSize int) (*File, error) {
+	if chunkSize < MinChunkSize || chunkSize > MaxChunkSize {
+		return nil, errors.New("encfile: invalid chunk size")
+	}
+	if len(keyID) > MaxKeyIDSize {
+		return nil, errors.New("encfile: key ID too long")
+	}
+
+	header := make([]byte, fixedHeaderSize+len(keyID))
+	copy(header, magic)
+	header[4] = version
+	binary.BigEndian.PutUint32(header[5:], uint32(chunkSize))
+	if _, err := rand.Read(header[9:25]); err != nil {
+		return nil, err
+	}
+	header[fixedHeaderSize-1] = byte(len(keyID))
+	copy(header[fixedHeaderSize:], keyID)
+
+	f, err := newFile(b, key, header)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.Truncate(0); err != nil {
+		return nil, err
+	}
+	if _, err := b.WriteAt(header, 0); err != nil {
+		return nil, err
+	}
+	if err := f.writeChunk(0, nil, true); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// ReadKeyID returns the key ID in the header of the encrypted file b.
+func ReadKeyID(b Backend) ([]byte, error) {
+	header, err := readHeader(b)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), header[fixedHeaderSize:]...), nil
+}
+
+// Open opens the encrypted file b with key, which must be the one named
+// by its key ID (see ReadKeyID). It authenticates the last chunk, so the
+// size is known to be the one last written. The write counter in the
+// header is trusted as found; see the package documentation.
+func Open(b Backend, key []byte) (*File, error) {
+	header, err := readHeader(b)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := newFile(b, key, header)
+	if err != nil {
+		return nil, err
+	}
+	f.next = binary.BigEndian.Uint64(header[counterOffset:])
+	f.reserved = f.next
+
+	fi, err := b.Stat()
+	if err != nil {
+		return nil, err
+	}
+	// Only the last chunk is short, and it is empty only when it is the
+	// only one
+	stored := fi.Size() - f.headerSize
+	if stored < ChunkOverhead {
+		return nil, errOpen
+	}
+	disk := int64(f.chunkSize + ChunkOverhead)
+	chunks := (stored + disk - 1) / disk
+	if rem := stored - (chunks-1)*disk; rem < ChunkOverhead || (rem == ChunkOverhead && chunks > 1) || chunks > maxChunks {
+		return nil, errOpen
+	}
+	f.size = stored - chunks*ChunkOverhead
+
+	if _, err := f.readChunk(chunks-1, true); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func readHeader(b Backend) ([]byte, error) {
+	header := make([]byte, fixedHeaderSize)
+	if _, err := b.ReadAt(header, 0); err != nil {
+		if err == io.EOF {
+			return nil, errFormat
+		}
+		return nil, err
+	}
+	if !bytes.Equal(header[:4], magic) {
+		return nil, errFormat
+	}
+	if header[4] != version {
+		return nil, errors.New("encfile: unsupported version")
+	}
+
+	header = append(header, make([]byte, header[fixedHeaderSize-1])...)
+	if _, err := b.ReadAt(header[fixedHeaderSize:], fixedHeaderSize); err != nil {
+		if err == io.EOF {
+			return nil, errFormat
+		}
+		return nil, err
+	}
+
+	return header, nil
+}
+
+func newFile(b Backend, key, header []byte) (*File, error) {
+	if len(key) != 16 && len(key) != 32 {
+		return nil, errors.New("encfile: key must be 16 or 32 bytes")
+	}
+	chunkSize := int(binary.BigEndian.Uint32(header[5:]))
+	if chunkSize < MinChunkSize || chunkSize > MaxChunkSize {
+		return nil, errFormat
+	}
+
+	block, err := aes.NewCipher(hkdf.Key(key, header[9:25], []byte("encfile chunk key"), len(key)))
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &File{
+		b:          b,
+		aead:       aead,
+		keyID:      append([]byte(nil), header[fixedHeaderSize:]...),
+		chunkSize:  chunkSize,
+		headerSize: int64(len(header)),
+		plain:      make([]byte, chunkSize),
+		sealed:     make([]byte, chunkSize+ChunkOverhead),
+	}, nil
+}
+
+// KeyID returns the key ID of the file.
+func (f *File) KeyID() []byte {
+	return append([]byte(nil), f.keyID...)
+}
+
+// ChunkSize returns the plaintext size of a chunk.
+func (f *File) ChunkSize() int {
+	return f.chunkSize
+}
+
+// Size returns the plaintext size of the file.
+func (f *File) Size() int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.size
+}
+
+// lastChunk returns the index of the last chunk of a file of size bytes.
+func (f *File) lastChunk(size int64) int64 {
+	if size == 0 {
+		return 0
+	}
+	return (size - 1) / int64(f.chunkSize)
+}
+
+// chunkLen returns the plaintext length of chunk i of a file of size
+// bytes.
+func (f *File) chunkLen(i, size int64) int {
+	n := size - i*int64(f.chunkSize)
+	if n > int64(f.chunkSize) {
+		n = int64(f.chunkSize)
+	}
+	return int(n)
+}
+
+func (f *File) chunkOffset(i int64) int64 {
+	return f.headerSize + i*int64(f.chunkSize+ChunkOverhead)
+}
+
+func (f *File) setNonce(i int64, counter uint64) {
+	binary.BigEndian.PutUint32(f.nonce[:4], uint32(i))
+	binary.BigEndian.PutUint64(f.nonce[4:], counter)
+}
+
+func additionalData(last bool) []byte {
+	if last {
+		return []byte{1}
+	}
+	return []byte{0}
+}
+
+// readChunk returns the authenticated plaintext of chunk i of the
+// current size, in f.plain.
+func (f *File) readChunk(i int64, last bool) ([]byte, error) {
+	sealed := f.sealed[:f.chunkLen(i, f.size)+ChunkOverhead]
+	if _, err := f.b.ReadAt(sealed, f.chunkOffset(i)); err != nil {
+		if err == io.EOF {
+			return nil, errOpen
+		}
+		return nil, err
+	}
+
+	f.setNonce(i, binary.BigEndian.Uint64(sealed))
+	plain, err := f.aead.Open(f.plain[:0], f.nonce[:], sealed[8:], additionalData(last))
+	if err != nil {
+		return nil, errOpen
+	}
+
+	return plain, nil
+}
+
+// writeChunk seals plain as chunk i under a fresh write counter.
+func (f *File) writeChunk(i int64, plain []byte, last bool) error {
+	if i >= maxChunks {
+		return errTooLong
+	}
+	if f.next >= f.reserved {
+		var reserve [8]byte
+		binary.BigEndian.PutUint64(reserve[:], f.next+counterWindow)
+		if _, err := f.b.WriteAt(reserve[:], counterOffset); err != nil {
+			return err
+		}
+		if err := f.b.Sync(); err != nil {
+			return err
+		}
+		f.reserved = f.next + counterWindow
+	}
+
+	binary.BigEndian.PutUint64(f.sealed, f.next)
+	f.setNonce(i, f.next)
+	f.next++
+
+	sealed := f.aead.Seal(f.sealed[:8], f.nonce[:], plain, additionalData(last))
+	_, err := f.b.WriteAt(sealed, f.chunkOffset(i))
+	return err
+}
+
+// ReadAt reads len(p) bytes at offset off of the plaintext.
+func (f *File) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errOffset
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	last := f.lastChunk(f.size)
+	n := 0
+	for n < len(p) && off < f.size {
+		i := off / int64(f.chunkSize)
+		plain, err := f.readChunk(i, i == last)
+		if err != nil {
+			return n, err
+		}
+		m := copy(p[n:], plain[off-i*int64(f.chunkSize):])
+		n += m
+		off += int64(m)
+	}
+
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// WriteAt writes p at offset off of the plaintext, growing the file, with
+// zeros before off if off is past the end, as needed.
+func (f *File) WriteAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errOffset
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if err := f.grow(off); err != nil {
+		return 0, err
+	}
+	if err := f.write(p, off); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// write writes p at off, which is at most the size, rewriting every chunk
+// it touches and the old last chunk if it is no longer the last.
+func (f *File) write(p []byte, off int64) error {
+	end := off + int64(len(p))
+	newSize := f.size
+	if end > newSize {
+		newSize = end
+	}
+	if f.lastChunk(newSize) >= maxChunks {
+		return errTooLong
+	}
+
+	oldLast, newLast := f.lastChunk(f.size), f.lastChunk(newSize)
+	first := off / int64(f.chunkSize)
+	if newLast > oldLast && oldLast < first {
+		first = oldLast
+	}
+
+	for i := first; i <= (end-1)/int64(f.chunkSize); i++ {
+		var old []byte
+		if i <= oldLast {
+			var err error
+			if old, err = f.readChunk(i, i == oldLast); err != nil {
+				return err
+			}
+		}
+
+		// old is in f.plain, so it is extended in place
+		start := i * int64(f.chunkSize)
+		plain := f.plain[:f.chunkLen(i, newSize)]
+		for j := len(old); j < len(plain); j++ {
+			plain[j] = 0
+		}
+		if end > start && off < start+int64(len(plain)) {
+			lo, hi := off-start, end-start
+			if lo < 0 {
+				lo = 0
+			}
+			if hi > int64(len(plain)) {
+				hi = int64(len(plain))
+			}
+			copy(plain[lo:hi], p[start+lo-off:])
+		}
+
+		if err := f.writeChunk(i, plain, i == newLast); err != nil {
+			return err
+		}
+	}
+
+	f.size = newSize
+	return nil
+}
+
+// grow extends the file with zeros to size bytes, a chunk at a time.
+func (f *File) grow(size int64) error {
+	zero := make([]byte, f.chunkSize)
+	for f.size < size {
+		n := size - f.size
+		if n > int64(f.chunkSize) {
+			n = int64(f.chunkSize)
+		}
+		if err := f.write(zero[:n], f.size); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Truncate changes the plaintext size of the file to size bytes, adding
+// zeros when it grows.
+func (f *File) Truncate(size int64) error {
+	if size < 0 {
+		return errOffset
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if size >= f.size {
+		return f.grow(size)
+	}
+
+	// The new last chunk is resealed as the last
+	i := f.lastChunk(size)
+	plain, err := f.readChunk(i, i == f.lastChunk(f.size))
+	if err != nil {
+		return err
+	}
+	n := f.chunkLen(i, size)
+	if err := f.writeChunk(i, plain[:n], true); err != nil {
+		return err
+	}
+	if err := f.b.Truncate(f.chunkOffset(i) + int64(n+ChunkOverhead)); err != nil {
+		return err
+	}
+	f.size = size
+
+	return nil
+}
+
+// Sync commits the backend to stable storage.
+func (f *File) Sync() error {
+	return f.b.Sync()
+}
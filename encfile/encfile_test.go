@@ -0,0 +1,295 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package encfile_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/encfile"
+)
+
+const chunkSize = encfile.MinChunkSize
+
+func tempBackend(t *testing.T) *os.File {
+	t.Helper()
+
+	b, err := os.Create(filepath.Join(t.TempDir(), "image"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { b.Close() })
+
+	return b
+}
+
+func checkContents(t *testing.T, f *encfile.File, want []byte) {
+	t.Helper()
+
+	if f.Size() != int64(len(want)) {
+		t.Fatalf("size %d, want %d", f.Size(), len(want))
+	}
+	got := make([]byte, len(want)+1)
+	n, err := f.ReadAt(got, 0)
+	if n != len(want) || err != io.EOF || !bytes.Equal(got[:n], want) {
+		t.Fatalf("ReadAt returned %d, %v; contents differ: %t", n, err, !bytes.Equal(got[:n], want))
+	}
+}
+
+// TestFile runs random writes, truncations and reads against a plain
+// copy of the contents, reopening the file now and then.
+func TestFile(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for _, keySize := range []int{16, 32} {
+		key := make([]byte, keySize)
+		r.Read(key)
+		b := tempBackend(t)
+
+		f, err := encfile.Create(b, key, []byte("vm-images/1"), chunkSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var model []byte
+		checkContents(t, f, model)
+
+		for op := 0; op < 400; op++ {
+			switch r.Intn(10) {
+			case 0:
+				size := r.Int63n(5 * chunkSize)
+				if err := f.Truncate(size); err != nil {
+					t.Fatal(err)
+				}
+				if size < int64(len(model)) {
+					model = model[:size]
+				} else {
+					model = append(model, make([]byte, size-int64(len(model)))...)
+				}
+			case 1:
+				if f, err = encfile.Open(b, key); err != nil {
+					t.Fatalf("op %d: Open: %v", op, err)
+				}
+			default:
+				off := r.Int63n(int64(len(model)) + 2*chunkSize)
+				p := make([]byte, r.Intn(3*chunkSize))
+				r.Read(p)
+				if n, err := f.WriteAt(p, off); n != len(p) || err != nil {
+					t.Fatalf("op %d: WriteAt returned %d, %v", op, n, err)
+				}
+				if end := off + int64(len(p)); end > int64(len(model)) {
+					model = append(model, make([]byte, end-int64(len(model)))...)
+				}
+				copy(model[off:], p)
+			}
+
+			// A random read
+			if len(model) > 0 {
+				off := r.Int63n(int64(len(model)))
+				p := make([]byte, r.Intn(2*chunkSize)+1)
+				n, err := f.ReadAt(p, off)
+				want := model[off:]
+				if len(want) > len(p) {
+					want = want[:len(p)]
+				}
+				if n != len(want) || !bytes.Equal(p[:n], want) {
+					t.Fatalf("op %d: ReadAt(%d, %d) returned %d bytes, %v", op, len(p), off, n, err)
+				}
+				if (n < len(p)) != (err == io.EOF) {
+					t.Fatalf("op %d: ReadAt returned %d of %d bytes with %v", op, n, len(p), err)
+				}
+			}
+		}
+		checkContents(t, f, model)
+
+		// The ciphertext is the plaintext and the overheads
+		fi, _ := b.Stat()
+		chunks := (int64(len(model)) + chunkSize - 1) / chunkSize
+		if chunks == 0 {
+			chunks = 1
+		}
+		header := int64(4 + 1 + 4 + 16 + 8 + 1 + len("vm-images/1"))
+		if want := header + int64(len(model)) + chunks*encfile.ChunkOverhead; fi.Size() != want {
+			t.Errorf("backend holds %d bytes, want %d", fi.Size(), want)
+		}
+	}
+}
+
+func TestFileHeader(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	b := tempBackend(t)
+	key := make([]byte, 32)
+	f, err := encfile.Create(b, key, []byte("key-7"), encfile.DefaultChunkSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteAt([]byte("hello"), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := encfile.ReadKeyID(b)
+	if err != nil || string(id) != "key-7" {
+		t.Fatalf("ReadKeyID returned %q, %v", id, err)
+	}
+	f, err = encfile.Open(b, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(f.KeyID()) != "key-7" || f.ChunkSize() != encfile.DefaultChunkSize {
+		t.Errorf("key ID %q and chunk size %d", f.KeyID(), f.ChunkSize())
+	}
+	checkContents(t, f, []byte("hello"))
+
+	if _, err := encfile.Open(b, make([]byte, 16)); err == nil {
+		t.Error("opened with another key")
+	}
+	other := append([]byte(nil), key...)
+	other[0] ^= 1
+	if _, err := encfile.Open(b, other); err == nil {
+		t.Error("opened with another key")
+	}
+
+	plain := tempBackend(t)
+	plain.WriteAt([]byte("not encrypted at all, just text"), 0)
+	if _, err := encfile.Open(plain, key); err == nil {
+		t.Error("opened a plain file")
+	}
+	if _, err := encfile.Create(b, key, make([]byte, encfile.MaxKeyIDSize+1), chunkSize); err == nil {
+		t.Error("accepted a long key ID")
+	}
+	for _, size := range []int{0, encfile.MinChunkSize - 1, encfile.MaxChunkSize + 1} {
+		if _, err := encfile.Create(b, key, nil, size); err == nil {
+			t.Errorf("accepted chunk size %d", size)
+		}
+	}
+}
+
+func TestFileTampering(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	key := make([]byte, 16)
+	msg := bytes.Repeat([]byte("0123456789abcdef"), 4*chunkSize/16+3)
+	header := int64(4 + 1 + 4 + 16 + 8 + 1)
+	disk := int64(chunkSize + encfile.ChunkOverhead)
+
+	setup := func() *os.File {
+		b := tempBackend(t)
+		f, err := encfile.Create(b, key, nil, chunkSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.WriteAt(msg, 0); err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	// Cutting off whole chunks
+	b := setup()
+	b.Truncate(header + 4*disk)
+	if _, err := encfile.Open(b, key); err == nil {
+		t.Error("opened a file cut at a chunk boundary")
+	}
+
+	// Modifying or swapping chunks
+	b = setup()
+	chunk0 := make([]byte, disk)
+	chunk1 := make([]byte, disk)
+	b.ReadAt(chunk0, header)
+	b.ReadAt(chunk1, header+disk)
+	b.WriteAt(chunk1, header)
+	b.WriteAt(chunk0, header+disk)
+	f, err := encfile.Open(b, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.ReadAt(make([]byte, 1), 0); err == nil {
+		t.Error("read a chunk moved to another index")
+	}
+	if _, err := f.ReadAt(make([]byte, 1), 3*chunkSize); err != nil {
+		t.Errorf("untouched chunk: %v", err)
+	}
+	var c [1]byte
+	b.ReadAt(c[:], header+2*disk+20)
+	b.WriteAt([]byte{c[0] ^ 1}, header+2*disk+20)
+	if _, err := f.ReadAt(make([]byte, 1), 2*chunkSize); err == nil {
+		t.Error("read a modified chunk")
+	}
+	if _, err := f.WriteAt([]byte{1}, 2*chunkSize+1); err == nil {
+		t.Error("wrote into a modified chunk")
+	}
+}
+
+// Every chunk written gets a fresh write counter, even after truncating
+// and growing the file or reopening it.
+func TestFileWriteCounters(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	key := make([]byte, 16)
+	b := tempBackend(t)
+	f, err := encfile.Create(b, key, nil, chunkSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := int64(4 + 1 + 4 + 16 + 8 + 1)
+
+	seen := map[uint64]bool{}
+	counter := func() uint64 {
+		var c [8]byte
+		b.ReadAt(c[:], header)
+		return binary.BigEndian.Uint64(c[:])
+	}
+	record := func() {
+		c := counter()
+		if seen[c] {
+			t.Fatalf("write counter %d used twice for chunk 0", c)
+		}
+		seen[c] = true
+	}
+
+	record()
+	for i := 0; i < 5; i++ {
+		f.WriteAt([]byte("same data"), 0)
+		record()
+		f.Truncate(0)
+		record()
+		if f, err = encfile.Open(b, key); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package gcmsiv implements AES-GCM-SIV, RFC 8452, a nonce misuse
+// resistant AEAD: reusing a nonce only reveals whether the same message
+// was sealed twice with it.
+//
+// The key derivation, tag encryption and CTR keystream go through the
+// accelerated AES, the keystream in batches. POLYVAL comes from package
+// ghash, which multiplies with PCLMULQDQ on amd64.
+package gcmsiv
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cipher"
+	"github.com/surendarchandra/crypto/ghash"
+	"github.com/surendarchandra/crypto/internal/alias"
+	"github.com/surendarchandra/crypto/internal/ctrmode"
+)
+
+const (
+	// NonceSize is the size of the GCM-SIV nonce.
+	NonceSize = 12
+
+	// TagSize is the size of the GCM-SIV tag.
+	TagSize = 16
+
+	// maxLen is the limit on plaintext and additional data, 2^36 bytes
+	maxLen = 1 << 36
+)
+
+var errOpen = errors.New("gcmsiv: message authentication failed")
+
+type gcmsiv struct {
+	block  cipher.Block
+	keyLen int
+}
+
+// New returns AES-GCM-SIV for a 16 byte (AES-128-GCM-SIV) or 32 byte
+// (AES-256-GCM-SIV) key. The AEAD is safe for concurrent use.
+func New(key []byte) (cipher.AEAD, error) {
+	if len(key) != 16 && len(key) != 32 {
+		return nil, errors.New("gcmsiv: key must be 16 or 32 bytes")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gcmsiv{block: block, keyLen: len(key)}, nil
+}
+
+func (*gcmsiv) NonceSize() int {
+	return NonceSize
+}
+
+func (*gcmsiv) Overhead() int {
+	return TagSize
+}
+
+// deriveKeys returns the message authentication key and a block for the
+// message encryption key (RFC 8452 section 4): the first 8 bytes of the
+// encryptions of LE32(i) || nonce.
+func (g *gcmsiv) deriveKeys(nonce []byte) ([]byte, cipher.Block, error) {
+	n := 2 + g.keyLen/8
+
+	var in, out [6 * aes.BlockSize]byte
+	for i := 0; i < n; i++ {
+		binary.LittleEndian.PutUint32(in[i*aes.BlockSize:], uint32(i))
+		copy(in[i*aes.BlockSize+4:], nonce)
+	}
+	if err := cipher.EncryptBlocks(g.block, out[:n*aes.BlockSize], in[:n*aes.BlockSize]); err != nil {
+		return nil, nil, err
+	}
+
+	keys := make([]byte, 8*n)
+	for i := 0; i < n; i++ {
+		copy(keys[8*i:], out[i*aes.BlockSize:i*aes.BlockSize+8])
+	}
+
+	enc, err := aes.NewCipher(keys[16:])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return keys[:16], enc, nil
+}
+
+// tag computes the tag over the plaintext (RFC 8452 section 4).
+func tag(enc cipher.Block, authKey, nonce, plaintext, additionalData []byte) ([TagSize]byte, error) {
+	var s [TagSize]byte
+
+	p, err := ghash.NewPOLYVAL(authKey)
+	if err != nil {
+		return s, err
+	}
+	p.Write(additionalData)
+	p.Pad()
+	p.Write(plaintext)
+	p.Pad()
+
+	var lengths [16]byte
+	binary.LittleEndian.PutUint64(lengths[:8], uint64(len(additionalData))*8)
+	binary.LittleEndian.PutUint64(lengths[8:], uint64(len(plaintext))*8)
+	p.Write(lengths[:])
+
+	p.Sum(s[:0])
+	for i := range nonce {
+		s[i] ^= nonce[i]
+	}
+	s[15] &= 0x7f
+	err = cipher.EncryptBlock(enc, s[:], s[:])
+
+	return s, err
+}
+
+// ctr applies the keystream starting at the tag with its top bit set.
+// The counter is the low 32 bits, little endian, and wraps.
+func ctr(enc cipher.Block, dst, src []byte, tag [TagSize]byte) error {
+	counter := tag
+	counter[15] |= 0x80
+	encrypt := func(dst, src []byte) error { return cipher.EncryptBlocks(enc, dst, src) }
+	return ctrmode.XORKeyStream(encrypt, dst, src, counter[:], ctrmode.IncrementLE32)
+}
+
+func (g *gcmsiv) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != NonceSize {
+		panic("gcmsiv: incorrect nonce length given to GCM-SIV")
+	}
+	if uint64(len(plaintext)) > maxLen || uint64(len(additionalData)) > maxLen {
+		panic("gcmsiv: message too large for GCM-SIV")
+	}
+
+	authKey, enc, err := g.deriveKeys(nonce)
+	if err != nil {
+		panic("gcmsiv: " + err.Error())
+	}
+	t, err := tag(enc, authKey, nonce, plaintext, additionalData)
+	if err != nil {
+		panic("gcmsiv: " + err.Error())
+	}
+
+	ret, out := alias.SliceForAppend(dst, len(plaintext)+TagSize)
+	if err := ctr(enc, out, plaintext, t); err != nil {
+		panic("gcmsiv: " + err.Error())
+	}
+	copy(out[len(plaintext):], t[:])
+
+	return ret
+}
+
+func (g *gcmsiv) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != NonceSize {
+		panic("gcmsiv: incorrect nonce length given to GCM-SIV")
+	}
+	if len(ciphertext) < TagSize ||
+		uint64(len(ciphertext)) > maxLen+TagSize || uint64(len(additionalData)) > maxLen {
+		return nil, errOpen
+	}
+
+	var expected [TagSize]byte
+	copy(expected[:], ciphertext[len(ciphertext)-TagSize:])
+	ciphertext = ciphertext[:len(ciphertext)-TagSize]
+
+	authKey, enc, err := g.deriveKeys(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, out := alias.SliceForAppend(dst, len(ciphertext))
+	if err := ctr(enc, out, ciphertext, expected); err != nil {
+		return nil, err
+	}
+
+	t, err := tag(enc, authKey, nonce, out, additionalData)
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare(t[:], expected[:]) != 1 {
+		for i := range out {
+			out[i] = 0
+		}
+		return nil, errOpen
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,189 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package gcmsiv_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"sync"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cipher"
+	"github.com/surendarchandra/crypto/gcmsiv"
+)
+
+func fromHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// A few of the RFC 8452 appendix C vectors; the full set runs with the
+// Wycheproof vectors.
+var gcmsivTests = []struct {
+	key, nonce, plaintext, ad, result string
+}{
+	{
+		"01000000000000000000000000000000",
+		"030000000000000000000000",
+		"",
+		"",
+		"dc20e2d83f25705bb49e439eca56de25",
+	},
+	{
+		"01000000000000000000000000000000",
+		"030000000000000000000000",
+		"0100000000000000",
+		"",
+		"b5d839330ac7b786578782fff6013b815b287c22493a364c",
+	},
+	{
+		"0100000000000000000000000000000000000000000000000000000000000000",
+		"030000000000000000000000",
+		"",
+		"",
+		"07f5f4169bbf55a8400cd47ea6fd400f",
+	},
+}
+
+func TestGCMSIV(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	for i, tc := range gcmsivTests {
+		aead, err := gcmsiv.New(fromHex(tc.key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		nonce, pt, ad := fromHex(tc.nonce), fromHex(tc.plaintext), fromHex(tc.ad)
+
+		ct := aead.Seal(nil, nonce, pt, ad)
+		if got := hex.EncodeToString(ct); got != tc.result {
+			t.Errorf("#%d: got %s, want %s", i, got, tc.result)
+			continue
+		}
+
+		got, err := aead.Open(nil, nonce, ct, ad)
+		if err != nil {
+			t.Errorf("#%d: Open failed: %v", i, err)
+		} else if !bytes.Equal(got, pt) {
+			t.Errorf("#%d: got %x, want %x", i, got, pt)
+		}
+
+		ct[len(ct)-1] ^= 0x80
+		if _, err := aead.Open(nil, nonce, ct, ad); err == nil {
+			t.Errorf("#%d: opened a modified tag", i)
+		}
+	}
+
+	if _, err := gcmsiv.New(make([]byte, 24)); err == nil {
+		t.Error("accepted a 24 byte key")
+	}
+}
+
+func TestGCMSIVConcurrent(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	aead, err := gcmsiv.New(make([]byte, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := bytes.Repeat([]byte("gcm-siv"), 100)
+	nonce := make([]byte, gcmsiv.NonceSize)
+	want := aead.Seal(nil, nonce, msg, nil)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				if got := aead.Seal(nil, nonce, msg, nil); !bytes.Equal(got, want) {
+					t.Error("concurrent Seal differs")
+					return
+				}
+				if _, err := aead.Open(nil, nonce, want, nil); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func benchmarkSeal(b *testing.B, aead cipher.AEAD, buf []byte) {
+	b.SetBytes(int64(len(buf)))
+
+	var nonce [12]byte
+	var ad [13]byte
+	var out []byte
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out = aead.Seal(out[:0], nonce[:], buf, ad[:])
+	}
+}
+
+func newGCMSIV(b *testing.B) cipher.AEAD {
+	aead, err := gcmsiv.New(make([]byte, 16))
+	if err != nil {
+		b.Fatal(err)
+	}
+	return aead
+}
+
+func newGCM(b *testing.B) cipher.AEAD {
+	block, err := aes.NewCipher(make([]byte, 16))
+	if err != nil {
+		b.Fatal(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return aead
+}
+
+func BenchmarkAESGCMSIVSeal1K(b *testing.B) {
+	benchmarkSeal(b, newGCMSIV(b), make([]byte, 1024))
+}
+
+func BenchmarkAESGCMSIVSeal8K(b *testing.B) {
+	benchmarkSeal(b, newGCMSIV(b), make([]byte, 8*1024))
+}
+
+func BenchmarkAESGCMSeal1K(b *testing.B) {
+	benchmarkSeal(b, newGCM(b), make([]byte, 1024))
+}
+
+func BenchmarkAESGCMSeal8K(b *testing.B) {
+	benchmarkSeal(b, newGCM(b), make([]byte, 8*1024))
+}
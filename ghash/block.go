@@ -0,0 +1,158 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ghash
+
+import (
+	"errors"
+
+	"github.com/surendarchandra/crypto/cipher"
+)
+
+// chunkSize is how much data is handed to the GCM at a time.
+const chunkSize = 4096
+
+// accelerated computes GHASH through the GCM of a block. The tag of GCM
+// with the zero nonce over additional data A alone is
+//
+//	(GHASH(A) ^ L) * H ^ E(K, J0)
+//
+// where L is the length block, so GHASH(A) falls out with one multiply
+// by the inverse of H. Chunks are chained with GHASH(X || Y) =
+// GHASH(X) * H^m ^ GHASH(Y), for Y of m blocks.
+type accelerated struct {
+	block cipher.Block
+
+	// tag0 is E(K, J0), the tag for no data
+	tag0 [Size]byte
+
+	// h is H, hinv its inverse times x and hChunk H to the number of
+	// blocks in a chunk, all byte reversed
+	h0, h1           uint64
+	hinv0, hinv1     uint64
+	hChunk0, hChunk1 uint64
+
+	chunk [chunkSize]byte
+	n     int
+}
+
+// NewWithBlock returns GHASH keyed with the hash key of block, E(K, 0),
+// as GCM uses it, accelerated by the block's GCM mode.
+func NewWithBlock(block cipher.Block) (*Hash, error) {
+	// Blocks without GCM support (AES-192 and XTS keys) have no tag
+	if block.GCMGetAuthTag() == nil {
+		return nil, errors.New("ghash: GCM not supported for this key size")
+	}
+
+	var h [BlockSize]byte
+	if err := cipher.EncryptBlock(block, h[:], h[:]); err != nil {
+		return nil, err
+	}
+
+	d, _ := New(h[:])
+	a := &accelerated{block: block}
+	a.tag0 = a.tag(nil)
+
+	a.h0, a.h1 = load(h[:], true)
+	a.hinv0, a.hinv1 = mulX(a.inverse())
+	a.hChunk0, a.hChunk1 = a.pow(chunkSize / BlockSize)
+	d.acc = a
+
+	return d, nil
+}
+
+// one is the multiplicative identity, byte reversed.
+const one = 1 << 63
+
+// pow returns H^m, byte reversed, for m up to the blocks in a chunk.
+func (a *accelerated) pow(m int) (uint64, uint64) {
+	r0, r1 := uint64(0), uint64(one)
+	for bit := chunkSize / BlockSize; bit > 0; bit >>= 1 {
+		r0, r1 = gmul(r0, r1, r0, r1)
+		if m&bit != 0 {
+			r0, r1 = gmul(r0, r1, a.h0, a.h1)
+		}
+	}
+	return r0, r1
+}
+
+// inverse returns H^-1 = H^(2^128 - 2), byte reversed.
+func (a *accelerated) inverse() (uint64, uint64) {
+	r0, r1 := uint64(0), uint64(one)
+	for i := 127; i >= 0; i-- {
+		r0, r1 = gmul(r0, r1, r0, r1)
+		if i != 0 {
+			r0, r1 = gmul(r0, r1, a.h0, a.h1)
+		}
+	}
+	return r0, r1
+}
+
+// tag returns the GCM tag for the zero nonce over data as additional
+// data and no plaintext.
+func (a *accelerated) tag(data []byte) [Size]byte {
+	var nonce [12]byte
+	var out, t [Size]byte
+
+	a.block.SetIV(nonce[:])
+	a.block.GCMAddAdditionalData(data)
+	a.block.Encrypt(out[:], nil, cipher.ModeGCM)
+	copy(t[:], a.block.GCMGetAuthTag())
+
+	return t
+}
+
+func (a *accelerated) write(d *Hash, p []byte) {
+	for len(p) > 0 {
+		m := copy(a.chunk[a.n:], p)
+		a.n += m
+		p = p[m:]
+		if a.n == chunkSize {
+			a.flush(d)
+		}
+	}
+}
+
+// flush hashes the buffered blocks into the state of d.
+func (a *accelerated) flush(d *Hash) {
+	if a.n == 0 {
+		return
+	}
+
+	t := a.tag(a.chunk[:a.n])
+	for i := range t {
+		t[i] ^= a.tag0[i]
+	}
+	s0, s1 := load(t[:], true)
+	y0, y1 := dot(s0, s1, a.hinv0, a.hinv1)
+	y1 ^= uint64(a.n) * 8
+
+	m0, m1 := a.hChunk0, a.hChunk1
+	if a.n != chunkSize {
+		m0, m1 = a.pow(a.n / BlockSize)
+	}
+	d.s0, d.s1 = gmul(d.s0, d.s1, m0, m1)
+	d.s0 ^= y0
+	d.s1 ^= y1
+
+	a.n = 0
+}
@@ -0,0 +1,52 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+//go:build amd64 && !purego
+
+package ghash
+
+import (
+	"github.com/klauspost/cpuid"
+)
+
+// useCLMUL is set if the blocks can go through PCLMULQDQ, with PSHUFB for
+// the byte reversal of GHASH.
+var useCLMUL = cpuid.CPU.Clmul() && cpuid.CPU.SSSE3()
+
+// mulBlocksCLMUL is mulBlocksGeneric with PCLMULQDQ. s and h are the
+// state and key, lo half first; reverse byte reverses each block.
+//
+//go:noescape
+func mulBlocksCLMUL(s, h *[2]uint64, p []byte, reverse bool)
+
+// mulBlocks folds p, a whole number of blocks, into the state of d.
+func (d *Hash) mulBlocks(p []byte) {
+	if !useCLMUL {
+		d.mulBlocksGeneric(p)
+		return
+	}
+
+	s := [2]uint64{d.s0, d.s1}
+	h := [2]uint64{d.h0, d.h1}
+	mulBlocksCLMUL(&s, &h, p, d.reverse)
+	d.s0, d.s1 = s[0], s[1]
+}
@@ -0,0 +1,100 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+//go:build amd64 && !purego
+
+#include "textflag.h"
+
+// The reduction constant x^128 + x^127 + x^126 + x^121 + 1 without its
+// top term, lo half first, and the PSHUFB mask that reverses 16 bytes.
+DATA polyvalPoly<>+0(SB)/8, $0x0000000000000001
+DATA polyvalPoly<>+8(SB)/8, $0xc200000000000000
+GLOBL polyvalPoly<>(SB), RODATA|NOPTR, $16
+
+DATA bswapMask<>+0(SB)/8, $0x08090a0b0c0d0e0f
+DATA bswapMask<>+8(SB)/8, $0x0001020304050607
+GLOBL bswapMask<>(SB), RODATA|NOPTR, $16
+
+// func mulBlocksCLMUL(s, h *[2]uint64, p []byte, reverse bool)
+//
+// For each block, s = (s ^ block) * h * x^-128: a schoolbook 256-bit
+// carry-less product in X5:X4, then two Montgomery folds of the low half
+// by the reduction constant, as dot does.
+TEXT ·mulBlocksCLMUL(SB), NOSPLIT, $0-41
+	MOVQ s+0(FP), DI
+	MOVQ h+8(FP), SI
+	MOVQ p_base+16(FP), DX
+	MOVQ p_len+24(FP), CX
+	MOVB reverse+40(FP), AX
+
+	MOVOU (DI), X0
+	MOVOU (SI), X1
+	MOVOU polyvalPoly<>(SB), X2
+	MOVOU bswapMask<>(SB), X3
+
+	SHRQ $4, CX
+	JZ   done
+
+loop:
+	MOVOU (DX), X4
+	TESTB AL, AL
+	JZ    xor
+	PSHUFB X3, X4
+
+xor:
+	PXOR X4, X0
+
+	// X5:X4 = X0 * X1
+	MOVOU     X0, X4
+	PCLMULQDQ $0x00, X1, X4
+	MOVOU     X0, X5
+	PCLMULQDQ $0x11, X1, X5
+	MOVOU     X0, X6
+	PCLMULQDQ $0x01, X1, X6
+	PCLMULQDQ $0x10, X1, X0
+	PXOR      X6, X0
+	MOVOU     X0, X6
+	PSLLDQ    $8, X0
+	PSRLDQ    $8, X6
+	PXOR      X0, X4
+	PXOR      X6, X5
+
+	// Fold the low 64 bits of X4 into the rest, twice
+	MOVOU     X4, X6
+	PCLMULQDQ $0x10, X2, X6
+	PSHUFD    $0x4e, X4, X4
+	PXOR      X6, X4
+	MOVOU     X4, X6
+	PCLMULQDQ $0x10, X2, X6
+	PSHUFD    $0x4e, X4, X4
+	PXOR      X6, X4
+
+	PXOR  X5, X4
+	MOVOU X4, X0
+
+	ADDQ $16, DX
+	DECQ CX
+	JNZ  loop
+
+done:
+	MOVOU X0, (DI)
+	RET
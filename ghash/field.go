@@ -0,0 +1,134 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ghash
+
+import "encoding/binary"
+
+// Field elements are little endian 128-bit integers in POLYVAL's
+// representation, lo and hi 64-bit halves. GHASH elements are mapped to
+// them by reversing the bytes (RFC 8452 appendix A). The carry-less
+// multiply uses integer multiplies on bits spread four apart, so it
+// takes the same time for every input.
+
+// bmul32 returns the carry-less product of x and y. Each product of
+// masked inputs sums at most 8 terms per bit position, so carries stay
+// within the three bits above it, which the final masks drop.
+func bmul32(x, y uint32) uint64 {
+	x0 := uint64(x & 0x11111111)
+	x1 := uint64(x & 0x22222222)
+	x2 := uint64(x & 0x44444444)
+	x3 := uint64(x & 0x88888888)
+	y0 := uint64(y & 0x11111111)
+	y1 := uint64(y & 0x22222222)
+	y2 := uint64(y & 0x44444444)
+	y3 := uint64(y & 0x88888888)
+
+	z0 := x0*y0 ^ x1*y3 ^ x2*y2 ^ x3*y1
+	z1 := x0*y1 ^ x1*y0 ^ x2*y3 ^ x3*y2
+	z2 := x0*y2 ^ x1*y1 ^ x2*y0 ^ x3*y3
+	z3 := x0*y3 ^ x1*y2 ^ x2*y1 ^ x3*y0
+
+	return z0&0x1111111111111111 | z1&0x2222222222222222 |
+		z2&0x4444444444444444 | z3&0x8888888888888888
+}
+
+// clmul64 returns the 128-bit carry-less product of x and y (Karatsuba).
+func clmul64(x, y uint64) (hi, lo uint64) {
+	x0, x1 := uint32(x), uint32(x>>32)
+	y0, y1 := uint32(y), uint32(y>>32)
+
+	a := bmul32(x0, y0)
+	b := bmul32(x1, y1)
+	c := bmul32(x0^x1, y0^y1) ^ a ^ b
+
+	return b ^ c>>32, a ^ c<<32
+}
+
+// dot returns a * b * x^-128 modulo x^128 + x^127 + x^126 + x^121 + 1.
+func dot(a0, a1, b0, b1 uint64) (uint64, uint64) {
+	// 256-bit product d3:d2:d1:d0
+	h1, h0 := clmul64(a1, b1)
+	l1, l0 := clmul64(a0, b0)
+	m1, m0 := clmul64(a0^a1, b0^b1)
+	m0 ^= l0 ^ h0
+	m1 ^= l1 ^ h1
+
+	d0 := l0
+	d1 := l1 ^ m0
+	d2 := h0 ^ m1
+	d3 := h1
+
+	// Montgomery reduction: adding q*P for q = d0, then d1 (times
+	// x^64), clears the low 128 bits, leaving the result in d3:d2.
+	d1 ^= d0<<57 ^ d0<<62 ^ d0<<63
+	d2 ^= d0 ^ d0>>7 ^ d0>>2 ^ d0>>1
+	d2 ^= d1<<57 ^ d1<<62 ^ d1<<63
+	d3 ^= d1 ^ d1>>7 ^ d1>>2 ^ d1>>1
+
+	return d2, d3
+}
+
+// mulBlocksGeneric folds p, a whole number of blocks, into the state of
+// d: s = (s ^ block) * h for each.
+func (d *Hash) mulBlocksGeneric(p []byte) {
+	for ; len(p) > 0; p = p[BlockSize:] {
+		x0, x1 := load(p, d.reverse)
+		d.s0, d.s1 = dot(d.s0^x0, d.s1^x1, d.h0, d.h1)
+	}
+}
+
+// mulX returns a * x, mulX_POLYVAL of RFC 8452 appendix A.
+func mulX(lo, hi uint64) (uint64, uint64) {
+	mask := -(hi >> 63)
+	hi = hi<<1 | lo>>63
+	lo <<= 1
+
+	return lo ^ 1&mask, hi ^ 0xc200000000000000&mask
+}
+
+// gmul returns the GHASH product of a and b, both byte reversed: dot
+// with b * x cancels the x^-128.
+func gmul(a0, a1, b0, b1 uint64) (uint64, uint64) {
+	b0, b1 = mulX(b0, b1)
+	return dot(a0, a1, b0, b1)
+}
+
+// load returns the field element for a block, byte reversing a GHASH
+// block.
+func load(b []byte, reverse bool) (uint64, uint64) {
+	if reverse {
+		return binary.BigEndian.Uint64(b[8:16]), binary.BigEndian.Uint64(b[:8])
+	}
+	return binary.LittleEndian.Uint64(b[:8]), binary.LittleEndian.Uint64(b[8:16])
+}
+
+// store is the inverse of load.
+func store(b []byte, lo, hi uint64, reverse bool) {
+	if reverse {
+		binary.BigEndian.PutUint64(b[:8], hi)
+		binary.BigEndian.PutUint64(b[8:16], lo)
+		return
+	}
+	binary.LittleEndian.PutUint64(b[:8], lo)
+	binary.LittleEndian.PutUint64(b[8:16], hi)
+}
@@ -0,0 +1,92 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ghash
+
+import (
+	"encoding/hex"
+	"math/rand"
+	"testing"
+)
+
+func TestBmul32(t *testing.T) {
+	for _, tc := range [][3]uint64{
+		{0, 0xffffffff, 0},
+		{1, 0xffffffff, 0xffffffff},
+		{3, 3, 5},
+		{0xffffffff, 0xffffffff, 0x5555555555555555},
+		{0x80000000, 0x80000000, 0x4000000000000000},
+	} {
+		if got := bmul32(uint32(tc[0]), uint32(tc[1])); got != tc[2] {
+			t.Errorf("bmul32(%#x, %#x) = %#x, want %#x", tc[0], tc[1], got, tc[2])
+		}
+	}
+}
+
+func TestGmulInverse(t *testing.T) {
+	h, _ := hex.DecodeString("66e94bd4ef8a2c3b884cfa59ca342b2e")
+
+	a := &accelerated{}
+	a.h0, a.h1 = load(h, true)
+	i0, i1 := a.inverse()
+	if p0, p1 := gmul(a.h0, a.h1, i0, i1); p0 != 0 || p1 != one {
+		t.Errorf("H * H^-1 = %016x%016x", p1, p0)
+	}
+	if p0, p1 := gmul(a.h0, a.h1, 0, one); p0 != a.h0 || p1 != a.h1 {
+		t.Errorf("H * 1 = %016x%016x", p1, p0)
+	}
+}
+
+// TestMulBlocks checks mulBlocks, which uses PCLMULQDQ where available,
+// against the software multiply.
+func TestMulBlocks(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	p := make([]byte, 64*BlockSize)
+
+	for i := 0; i < 100; i++ {
+		var key [BlockSize]byte
+		r.Read(key[:])
+		r.Read(p)
+		n := r.Intn(64) * BlockSize
+
+		for _, d := range []*Hash{mustNew(New(key[:])), mustNew(NewPOLYVAL(key[:]))} {
+			d.s0, d.s1 = r.Uint64(), r.Uint64()
+			want := *d
+			want.mulBlocksGeneric(p[:n])
+			d.mulBlocks(p[:n])
+			if d.s0 != want.s0 || d.s1 != want.s1 {
+				t.Fatalf("reverse %t, %d blocks: got %016x%016x, want %016x%016x",
+					d.reverse, n/BlockSize, d.s1, d.s0, want.s1, want.s0)
+			}
+		}
+	}
+	if !useCLMUL {
+		t.Log("PCLMULQDQ not used")
+	}
+}
+
+func mustNew(d *Hash, err error) *Hash {
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
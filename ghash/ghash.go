@@ -0,0 +1,165 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package ghash implements GHASH (SP 800-38D), the universal hash of GCM,
+// and POLYVAL (RFC 8452), its little endian counterpart in GCM-SIV, for
+// building other constructions. Both are keyed by a 16 byte hash key.
+//
+// New and NewPOLYVAL multiply with PCLMULQDQ on amd64 processors that
+// have it and in constant time software elsewhere. NewWithBlock
+// keys GHASH with the hash key of an AES block, E(K, 0), and runs the
+// data through the accelerated GCM of package aes.
+package ghash
+
+import (
+	"errors"
+)
+
+const (
+	// BlockSize is the block size of GHASH and POLYVAL in bytes.
+	BlockSize = 16
+
+	// Size is the size of the hash in bytes.
+	Size = 16
+)
+
+// Hash is a running GHASH or POLYVAL. Written data is hashed in 16 byte
+// blocks; a partial last block is zero padded by Pad or Sum. It
+// implements hash.Hash.
+type Hash struct {
+	// h is the key and s the state as field elements. A GHASH key is
+	// stored multiplied by x, so dot gives the GHASH product.
+	h0, h1 uint64
+	s0, s1 uint64
+
+	// reverse is set for GHASH, whose blocks are byte reversed
+	reverse bool
+
+	buf [BlockSize]byte
+	n   int
+
+	// acc is set by NewWithBlock
+	acc *accelerated
+}
+
+// New returns GHASH with the hash key h.
+func New(h []byte) (*Hash, error) {
+	if len(h) != BlockSize {
+		return nil, errors.New("ghash: hash key must be 16 bytes")
+	}
+
+	d := &Hash{reverse: true}
+	d.h0, d.h1 = mulX(load(h, true))
+
+	return d, nil
+}
+
+// NewPOLYVAL returns POLYVAL with the hash key h.
+func NewPOLYVAL(h []byte) (*Hash, error) {
+	if len(h) != BlockSize {
+		return nil, errors.New("ghash: hash key must be 16 bytes")
+	}
+
+	d := &Hash{}
+	d.h0, d.h1 = load(h, false)
+
+	return d, nil
+}
+
+func (d *Hash) Size() int {
+	return Size
+}
+
+func (d *Hash) BlockSize() int {
+	return BlockSize
+}
+
+func (d *Hash) Reset() {
+	d.s0, d.s1 = 0, 0
+	d.n = 0
+	if d.acc != nil {
+		d.acc.n = 0
+	}
+}
+
+// blocks hashes p, a whole number of blocks.
+func (d *Hash) blocks(p []byte) {
+	if d.acc != nil {
+		d.acc.write(d, p)
+		return
+	}
+
+	d.mulBlocks(p)
+}
+
+func (d *Hash) Write(p []byte) (int, error) {
+	written := len(p)
+
+	if d.n > 0 {
+		m := copy(d.buf[d.n:], p)
+		d.n += m
+		p = p[m:]
+		if d.n < BlockSize {
+			return written, nil
+		}
+		d.blocks(d.buf[:])
+		d.n = 0
+	}
+
+	full := len(p) / BlockSize * BlockSize
+	d.blocks(p[:full])
+	d.n = copy(d.buf[:], p[full:])
+
+	return written, nil
+}
+
+// Pad zero pads a partial block written so far, so the next write starts
+// a new block. GCM pads the additional data and the ciphertext this way.
+func (d *Hash) Pad() {
+	if d.n == 0 {
+		return
+	}
+	for i := d.n; i < BlockSize; i++ {
+		d.buf[i] = 0
+	}
+	d.blocks(d.buf[:])
+	d.n = 0
+}
+
+// Sum appends the hash of the data written so far, zero padded, to in.
+// It does not change the state.
+func (d *Hash) Sum(in []byte) []byte {
+	c := *d
+	if d.acc != nil {
+		acc := *d.acc
+		c.acc = &acc
+	}
+	c.Pad()
+	if c.acc != nil {
+		c.acc.flush(&c)
+	}
+
+	var out [Size]byte
+	store(out[:], c.s0, c.s1, c.reverse)
+
+	return append(in, out[:]...)
+}
@@ -0,0 +1,159 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ghash_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/rand"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cipher"
+	"github.com/surendarchandra/crypto/ghash"
+)
+
+func fromHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// RFC 8452 appendix A
+func TestPOLYVAL(t *testing.T) {
+	p, err := ghash.NewPOLYVAL(fromHex("25629347589242761d31f826ba4b757b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Write(fromHex("4f4f95668c83dfb6401762bb2d01a262"))
+	p.Write(fromHex("d1a24ddd2721d006bbe45f20d3c9f362"))
+
+	if got, want := hex.EncodeToString(p.Sum(nil)), "f7a3b47b846119fae5b7866cf5e5b77e"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+// The GHASH values of the GCM specification test cases 2 and 4
+// (McGrew and Viega, as used for the NIST validation).
+func TestGHASH(t *testing.T) {
+	tests := []struct {
+		h, ad, ct, lengths, want string
+	}{
+		{
+			"66e94bd4ef8a2c3b884cfa59ca342b2e",
+			"",
+			"0388dace60b6a392f328c2b971b2fe78",
+			"00000000000000000000000000000080",
+			"f38cbb1ad69223dcc3457ae5b6b0f885",
+		},
+		{
+			"b83b533708bf535d0aa6e52980d53b78",
+			"feedfacedeadbeeffeedfacedeadbeefabaddad2",
+			"42831ec2217774244b7221b784d0d49ce3aa212f2c02a4e035c17e2329aca12e" +
+				"21d514b25466931c7d8f6a5aac84aa051ba30b396a0aac973d58e091",
+			"00000000000000a000000000000001e0",
+			"698e57f70e6ecc7fd9463b7260a9ae5f",
+		},
+	}
+
+	for i, test := range tests {
+		g, err := ghash.New(fromHex(test.h))
+		if err != nil {
+			t.Fatal(err)
+		}
+		g.Write(fromHex(test.ad))
+		g.Pad()
+		g.Write(fromHex(test.ct))
+		g.Pad()
+		g.Write(fromHex(test.lengths))
+		if got := hex.EncodeToString(g.Sum(nil)); got != test.want {
+			t.Errorf("#%d: got %s, want %s", i, got, test.want)
+		}
+	}
+
+	if _, err := ghash.New(make([]byte, 15)); err == nil {
+		t.Error("accepted a 15 byte key")
+	}
+}
+
+// NewWithBlock must agree with New keyed by E(K, 0), across chunks,
+// partial blocks and Pad.
+func TestGHASHWithBlock(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	for _, key := range [][]byte{fromHex("feffe9928665731c6d6a8f9467308308"), make([]byte, 32)} {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		acc, err := ghash.NewWithBlock(block)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var h [16]byte
+		block.SetIV(h[:])
+		block.Encrypt(h[:], h[:], cipher.ModeCBC)
+		soft, err := ghash.New(h[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r := rand.New(rand.NewSource(1))
+		for _, n := range []int{0, 1, 16, 100, 4096, 4111, 10000} {
+			data := make([]byte, n)
+			r.Read(data)
+
+			acc.Reset()
+			soft.Reset()
+			for _, d := range []*ghash.Hash{acc, soft} {
+				d.Write(data[:n/3])
+				d.Pad()
+				d.Write(data[n/3:])
+			}
+			if got, want := acc.Sum(nil), soft.Sum(nil); !bytes.Equal(got, want) {
+				t.Errorf("key %d bytes, %d bytes: got %x, want %x", len(key), n, got, want)
+			}
+
+			// Sum leaves the state alone
+			acc.Write(data)
+			soft.Write(data)
+			if got, want := acc.Sum(nil), soft.Sum(nil); !bytes.Equal(got, want) {
+				t.Errorf("key %d bytes, %d bytes continued: got %x, want %x", len(key), n, got, want)
+			}
+		}
+	}
+
+	// AES-192 has no GCM
+	block, err := aes.NewCipher(make([]byte, 24))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ghash.NewWithBlock(block); err == nil {
+		t.Error("accepted an AES-192 key")
+	}
+}
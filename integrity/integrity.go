@@ -0,0 +1,533 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package integrity implements an authenticated sector store, in the
+// spirit of dm-integrity under dm-crypt's AEAD mode: each sector is
+// encrypted with AES-GCM under the nonce
+//
+//	sector number (uint64) | write generation (uint32)
+//
+// and its tag and generation are kept in a separate metadata region, so a
+// read fails with ErrAuth on a modified sector or tag (corruption), on a
+// sector stored at another place (mis-location) and on an older version
+// of a sector (rollback).
+//
+// The metadata region starts with a header,
+//
+//	magic "ISEC" | version 1 | sector size (uint32) | sectors (uint64) |
+//	salt (16 bytes)
+//
+// padded to 64 bytes, followed by an entry of MetadataEntrySize bytes
+// per sector,
+//
+//	generation (uint32) | tag (16 bytes)
+//
+// All integers are big-endian. Generation 0 marks a sector never written,
+// which reads as zeros. The data region holds the ciphertext of each
+// sector, as long as its plaintext.
+//
+// The GCM key is derived from the key and the salt with HKDF-SHA-256, so
+// formatting again with the same key never repeats a nonce. A write
+// stores the new generation and tag, then syncs the backing store if it
+// has a Sync method, and only then writes the data: the data of a
+// generation never reaches stable storage without its metadata, so a
+// crash in the middle leaves the sector failing authentication until it
+// is written again, never a generation to be used again. A backing store
+// without Sync must not reorder writes across a crash.
+//
+// A Store keeps the generations in memory, 4 bytes per sector, so
+// rolling back both a sector and its metadata is detected until the
+// Store is dropped. Across restarts, Digest authenticates all the
+// generations: an application that keeps the digest of the last Flush in
+// trusted storage and passes it to Open detects rollbacks of the
+// metadata as well.
+//
+// Open without a Digest trusts the generations it finds in the metadata.
+// If the metadata and data are rolled back together while the store is
+// closed, for example by restoring a snapshot or an older copy of the
+// backing store, that rollback is not detected. The sectors then read
+// back as their older versions, and the next writes use generations that
+// were already used, so GCM nonces are repeated under the key. That
+// leaks the XOR of the old and new plaintexts and allows forging tags.
+// The guarantee against reusing a nonce after a crash only holds against
+// crashes, not against such rollbacks, unless a Digest is used.
+package integrity
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cipher"
+	"github.com/surendarchandra/crypto/internal/hkdf"
+)
+
+const (
+	// DefaultSectorSize is the sector size of a Config that leaves it
+	// zero.
+	DefaultSectorSize = 4096
+
+	// MinSectorSize and MaxSectorSize bound the sector size, which must
+	// be a power of two.
+	MinSectorSize = 512
+	MaxSectorSize = 4096
+
+	// MetadataHeaderSize is the size of the header of the metadata
+	// region and MetadataEntrySize that of the entry of each sector.
+	MetadataHeaderSize = 64
+	MetadataEntrySize  = 4 + tagSize
+
+	// DigestSize is the size of the digest of the generations.
+	DigestSize = sha256.Size
+
+	version = 1
+	tagSize = 16
+
+	// chunkSize is how much data is read or written at a time
+	chunkSize = 64 * 1024
+)
+
+var (
+	// ErrAuth is returned when a sector fails authentication: it, or
+	// its metadata, was modified, moved or rolled back.
+	ErrAuth = errors.New("integrity: sector authentication failed")
+
+	errFormat = errors.New("integrity: not an integrity store")
+	errRange  = errors.New("integrity: sectors out of range")
+	errLength = errors.New("integrity: length not a multiple of the sector size")
+
+	magic = []byte("ISEC")
+)
+
+// A Backing holds the data and metadata regions; *os.File is one. If it
+// has a Sync method, WriteSectors calls it between the metadata and the
+// data of each write, and Flush calls it.
+type Backing interface {
+	io.ReaderAt
+	io.WriterAt
+}
+
+// Config describes the layout of a store on its backing store. The
+// regions must not overlap.
+type Config struct {
+	// SectorSize is the size of a sector, a power of two from
+	// MinSectorSize to MaxSectorSize; zero means DefaultSectorSize.
+	SectorSize int
+
+	// Sectors is the number of sectors of the store.
+	Sectors int64
+
+	// DataOffset is where the data region, of Sectors * SectorSize
+	// bytes, starts.
+	DataOffset int64
+
+	// MetadataOffset is where the metadata region, of MetadataSize
+	// (Sectors) bytes, starts.
+	MetadataOffset int64
+
+	// Digest, if set, is the Digest of the store when it was last
+	// flushed; Open fails if the generations do not match it. Without
+	// it, Open trusts the metadata, and a rollback of the metadata and
+	// data together goes undetected and leads to reused nonces.
+	Digest []byte
+}
+
+// MetadataSize returns the size of the metadata region for sectors.
+func MetadataSize(sectors int64) int64 {
+	return MetadataHeaderSize + sectors*MetadataEntrySize
+}
+
+// A Store is an authenticated sector store. It is safe for concurrent
+// use.
+type Store struct {
+	mu          sync.Mutex
+	b           Backing
+	aead        cipher.AEAD
+	digestKey   []byte
+	header      []byte
+	sectorSize  int
+	sectors     int64
+	dataOffset  int64
+	metaOffset  int64
+	generations []uint32
+
+	nonce      [12]byte
+	meta, data []byte
+	sealed     []byte
+}
+
+// check validates cfg and sets its defaults.
+func (cfg *Config) check() error {
+	if cfg.SectorSize == 0 {
+		cfg.SectorSize = DefaultSectorSize
+	}
+	if cfg.SectorSize < MinSectorSize || cfg.SectorSize > MaxSectorSize || cfg.SectorSize&(cfg.SectorSize-1) != 0 {
+		return errors.New("integrity: invalid sector size")
+	}
+	if cfg.Sectors <= 0 || cfg.Sectors > 1<<40 {
+		return errors.New("integrity: invalid number of sectors")
+	}
+	if cfg.DataOffset < 0 || cfg.MetadataOffset < 0 {
+		return errors.New("integrity: negative offset")
+	}
+
+	dataEnd := cfg.DataOffset + cfg.Sectors*int64(cfg.SectorSize)
+	metaEnd := cfg.MetadataOffset + MetadataSize(cfg.Sectors)
+	if cfg.DataOffset < metaEnd && cfg.MetadataOffset < dataEnd {
+		return errors.New("integrity: data and metadata regions overlap")
+	}
+
+	return nil
+}
+
+// Format formats the metadata region of b for a new store of cfg with a
+// 16 or 32 byte key. All sectors read as zeros until written.
+func Format(b Backing, key []byte, cfg Config) (*Store, error) {
+	if err := cfg.check(); err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, MetadataHeaderSize)
+	copy(header, magic)
+	header[4] = version
+	binary.BigEndian.PutUint32(header[5:], uint32(cfg.SectorSize))
+	binary.BigEndian.PutUint64(header[9:], uint64(cfg.Sectors))
+	if _, err := rand.Read(header[17:33]); err != nil {
+		return nil, err
+	}
+
+	s, err := newStore(b, key, header, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := b.WriteAt(header, cfg.MetadataOffset); err != nil {
+		return nil, err
+	}
+	zeros := make([]byte, chunkSize/MetadataEntrySize*MetadataEntrySize)
+	for off := int64(0); off < cfg.Sectors*MetadataEntrySize; off += int64(len(zeros)) {
+		n := cfg.Sectors*MetadataEntrySize - off
+		if n > int64(len(zeros)) {
+			n = int64(len(zeros))
+		}
+		if _, err := b.WriteAt(zeros[:n], cfg.MetadataOffset+MetadataHeaderSize+off); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// Open opens the store of cfg on b with its key, loading the generations
+// of the sectors and, if cfg.Digest is set, checking them against it.
+// With a nil cfg.Digest, the generations are trusted as found, so the
+// caller must know that the backing store was not rolled back while the
+// store was closed.
+func Open(b Backing, key []byte, cfg Config) (*Store, error) {
+	if err := cfg.check(); err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, MetadataHeaderSize)
+	if err := readMetadata(b, header, cfg.MetadataOffset); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(header[:4], magic) || header[4] != version {
+		return nil, errFormat
+	}
+	if binary.BigEndian.Uint32(header[5:]) != uint32(cfg.SectorSize) || binary.BigEndian.Uint64(header[9:]) != uint64(cfg.Sectors) {
+		return nil, errors.New("integrity: layout does not match the store")
+	}
+
+	s, err := newStore(b, key, header, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, chunkSize/MetadataEntrySize*MetadataEntrySize)
+	for i := int64(0); i < s.sectors; {
+		n := int64(len(buf) / MetadataEntrySize)
+		if n > s.sectors-i {
+			n = s.sectors - i
+		}
+		if err := readMetadata(b, buf[:n*MetadataEntrySize], s.entryOffset(i)); err != nil {
+			return nil, err
+		}
+		for j := int64(0); j < n; j++ {
+			s.generations[i+j] = binary.BigEndian.Uint32(buf[j*MetadataEntrySize:])
+		}
+		i += n
+	}
+
+	if cfg.Digest != nil && !hmac.Equal(s.Digest(), cfg.Digest) {
+		return nil, ErrAuth
+	}
+
+	return s, nil
+}
+
+// readMetadata reads all of p from the metadata region at off.
+func readMetadata(b Backing, p []byte, off int64) error {
+	n, err := b.ReadAt(p, off)
+	if n == len(p) {
+		return nil
+	}
+	if err == io.EOF {
+		return errFormat
+	}
+	return err
+}
+
+func newStore(b Backing, key, header []byte, cfg Config) (*Store, error) {
+	if len(key) != 16 && len(key) != 32 {
+		return nil, errors.New("integrity: key must be 16 or 32 bytes")
+	}
+
+	salt := header[17:33]
+	block, err := aes.NewCipher(hkdf.Key(key, salt, []byte("integrity sector key"), len(key)))
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{
+		b:           b,
+		aead:        aead,
+		digestKey:   hkdf.Key(key, salt, []byte("integrity digest key"), 32),
+		header:      header,
+		sectorSize:  cfg.SectorSize,
+		sectors:     cfg.Sectors,
+		dataOffset:  cfg.DataOffset,
+		metaOffset:  cfg.MetadataOffset,
+		generations: make([]uint32, cfg.Sectors),
+		meta:        make([]byte, chunkSize/cfg.SectorSize*MetadataEntrySize),
+		data:        make([]byte, chunkSize),
+		sealed:      make([]byte, cfg.SectorSize+tagSize),
+	}, nil
+}
+
+// SectorSize returns the sector size of s.
+func (s *Store) SectorSize() int {
+	return s.sectorSize
+}
+
+// Sectors returns the number of sectors of s.
+func (s *Store) Sectors() int64 {
+	return s.sectors
+}
+
+// Size returns the size of s in bytes.
+func (s *Store) Size() int64 {
+	return s.sectors * int64(s.sectorSize)
+}
+
+func (s *Store) entryOffset(sector int64) int64 {
+	return s.metaOffset + MetadataHeaderSize + sector*MetadataEntrySize
+}
+
+func (s *Store) setNonce(sector int64, generation uint32) {
+	binary.BigEndian.PutUint64(s.nonce[:8], uint64(sector))
+	binary.BigEndian.PutUint32(s.nonce[8:], generation)
+}
+
+func (s *Store) check(p []byte, sector int64) error {
+	if len(p)%s.sectorSize != 0 {
+		return errLength
+	}
+	if sector < 0 || sector > s.sectors || int64(len(p)/s.sectorSize) > s.sectors-sector {
+		return errRange
+	}
+
+	return nil
+}
+
+// ReadSectors reads and authenticates the sectors starting at sector
+// into p, whose length must be a multiple of the sector size. It fails
+// with ErrAuth if any of them fails authentication.
+func (s *Store) ReadSectors(p []byte, sector int64) error {
+	if err := s.check(p, sector); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ss := s.sectorSize
+	for len(p) > 0 {
+		n := len(p)
+		if n > len(s.data) {
+			n = len(s.data)
+		}
+		meta := s.meta[:n/ss*MetadataEntrySize]
+		data := s.data[:n]
+		if err := readFull(s.b, meta, s.entryOffset(sector)); err != nil {
+			return err
+		}
+		if err := readFull(s.b, data, s.dataOffset+sector*int64(ss)); err != nil {
+			return err
+		}
+
+		for i := 0; i < n/ss; i++ {
+			entry := meta[i*MetadataEntrySize:][:MetadataEntrySize]
+			generation := binary.BigEndian.Uint32(entry)
+			if generation != s.generations[sector] {
+				return ErrAuth
+			}
+
+			out := p[i*ss : (i+1)*ss]
+			if generation == 0 {
+				for j := range out {
+					out[j] = 0
+				}
+				sector++
+				continue
+			}
+
+			sealed := append(append(s.sealed[:0], data[i*ss:(i+1)*ss]...), entry[4:]...)
+			s.setNonce(sector, generation)
+			if _, err := s.aead.Open(out[:0], s.nonce[:], sealed, nil); err != nil {
+				return ErrAuth
+			}
+			sector++
+		}
+
+		p = p[n:]
+	}
+
+	return nil
+}
+
+// readFull reads all of p from the backing store, the bytes past its end
+// reading as zeros, which are never a written sector.
+func readFull(b Backing, p []byte, off int64) error {
+	n, err := b.ReadAt(p, off)
+	if err == io.EOF {
+		for i := n; i < len(p); i++ {
+			p[i] = 0
+		}
+		return nil
+	}
+
+	return err
+}
+
+// WriteSectors encrypts p and writes it to the sectors starting at
+// sector, each under its next generation. The length of p must be a
+// multiple of the sector size.
+func (s *Store) WriteSectors(p []byte, sector int64) error {
+	if err := s.check(p, sector); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ss := s.sectorSize
+	for len(p) > 0 {
+		n := len(p)
+		if n > len(s.data) {
+			n = len(s.data)
+		}
+		meta := s.meta[:n/ss*MetadataEntrySize]
+		data := s.data[:n]
+
+		for i := 0; i < n/ss; i++ {
+			generation := s.generations[sector+int64(i)] + 1
+			if generation == 0 {
+				return errors.New("integrity: sector generations exhausted")
+			}
+
+			s.setNonce(sector+int64(i), generation)
+			sealed := s.aead.Seal(s.sealed[:0], s.nonce[:], p[i*ss:(i+1)*ss], nil)
+			copy(data[i*ss:], sealed[:ss])
+			entry := meta[i*MetadataEntrySize:]
+			binary.BigEndian.PutUint32(entry, generation)
+			copy(entry[4:], sealed[ss:])
+		}
+
+		// The metadata goes first, and is made durable before the data
+		// under its nonces, so neither a failed write nor a crash
+		// leaves a generation to be used again
+		for i := 0; i < n/ss; i++ {
+			s.generations[sector+int64(i)]++
+		}
+		if _, err := s.b.WriteAt(meta, s.entryOffset(sector)); err != nil {
+			return err
+		}
+		if err := s.sync(); err != nil {
+			return err
+		}
+		if _, err := s.b.WriteAt(data, s.dataOffset+sector*int64(ss)); err != nil {
+			return err
+		}
+
+		p = p[n:]
+		sector += int64(n / ss)
+	}
+
+	return nil
+}
+
+// Digest returns an HMAC of the header and the generations of all
+// sectors, which changes with every write. Kept in trusted storage after
+// a Flush and passed to Open, it detects rollbacks of the metadata.
+func (s *Store) Digest() []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h := hmac.New(sha256.New, s.digestKey)
+	h.Write(s.header)
+	var buf [4096]byte
+	for i := 0; i < len(s.generations); {
+		n := 0
+		for ; n < len(buf) && i < len(s.generations); n += 4 {
+			binary.BigEndian.PutUint32(buf[n:], s.generations[i])
+			i++
+		}
+		h.Write(buf[:n])
+	}
+
+	return h.Sum(nil)
+}
+
+// Flush commits the writes to stable storage if the backing store has a
+// Sync method, as *os.File.
+func (s *Store) Flush() error {
+	return s.sync()
+}
+
+func (s *Store) sync() error {
+	if f, ok := s.b.(interface{ Sync() error }); ok {
+		return f.Sync()
+	}
+
+	return nil
+}
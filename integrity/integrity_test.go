@@ -0,0 +1,400 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package integrity_test
+
+import (
+	"bytes"
+	goaes "crypto/aes"
+	gocipher "crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/rand"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/integrity"
+	"github.com/surendarchandra/crypto/internal/backingtest"
+)
+
+// config puts the metadata after the data.
+func config(sectorSize int, sectors int64) integrity.Config {
+	return integrity.Config{
+		SectorSize:     sectorSize,
+		Sectors:        sectors,
+		DataOffset:     0,
+		MetadataOffset: sectors * int64(sectorSize),
+	}
+}
+
+// TestStore runs random writes and reads against a plain copy of the
+// contents, on a file and in memory, reopening the store with its
+// digest.
+func TestStore(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	backings := map[string]func() integrity.Backing{
+		"file":   func() integrity.Backing { return backingtest.TempFile(t) },
+		"memory": func() integrity.Backing { return new(backingtest.Mem) },
+	}
+	r := rand.New(rand.NewSource(1))
+	for name, backing := range backings {
+		for _, cfg := range []integrity.Config{
+			config(512, 100),
+			{Sectors: 40, DataOffset: integrity.MetadataSize(40), MetadataOffset: 0},
+		} {
+			key := make([]byte, 16+16*r.Intn(2))
+			r.Read(key)
+			b := backing()
+			s, err := integrity.Format(b, key, cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ss := s.SectorSize()
+			if s.Size() != s.Sectors()*int64(ss) || s.Sectors() != cfg.Sectors {
+				t.Fatalf("%s: %d sectors of %d bytes", name, s.Sectors(), ss)
+			}
+
+			// Unwritten sectors read as zeros
+			model := make([]byte, s.Size())
+			got := make([]byte, s.Size())
+			for i := range got {
+				got[i] = 1
+			}
+			if err := s.ReadSectors(got, 0); err != nil || !bytes.Equal(got, model) {
+				t.Fatalf("%s: ReadSectors of a new store returned %v", name, err)
+			}
+
+			for op := 0; op < 100; op++ {
+				sector := r.Int63n(s.Sectors())
+				n := r.Int63n(s.Sectors()-sector) + 1
+				if n > 20 {
+					n = 20
+				}
+				p := make([]byte, n*int64(ss))
+				r.Read(p)
+				if err := s.WriteSectors(p, sector); err != nil {
+					t.Fatalf("%s op %d: %v", name, op, err)
+				}
+				copy(model[sector*int64(ss):], p)
+
+				sector = r.Int63n(s.Sectors())
+				p = make([]byte, (r.Int63n(s.Sectors()-sector)+1)*int64(ss))
+				if err := s.ReadSectors(p, sector); err != nil || !bytes.Equal(p, model[sector*int64(ss):][:len(p)]) {
+					t.Fatalf("%s op %d: ReadSectors returned %v", name, op, err)
+				}
+			}
+
+			if err := s.Flush(); err != nil {
+				t.Fatal(err)
+			}
+			cfg.Digest = s.Digest()
+			if s, err = integrity.Open(b, key, cfg); err != nil {
+				t.Fatalf("%s: Open: %v", name, err)
+			}
+			if err := s.ReadSectors(got, 0); err != nil || !bytes.Equal(got, model) {
+				t.Fatalf("%s: ReadSectors of the reopened store returned %v", name, err)
+			}
+		}
+	}
+}
+
+// TestStoreFormat checks the stored sectors against crypto/cipher's GCM
+// under the key derived from the salt and the sector and generation
+// nonces.
+func TestStoreFormat(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	key := bytes.Repeat([]byte{7}, 32)
+	m := new(backingtest.Mem)
+	cfg := config(512, 8)
+	s, err := integrity.Format(m, key, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain := bytes.Repeat([]byte("sector 5 "), 64)[:512]
+	for i := 0; i < 3; i++ {
+		if err := s.WriteSectors(plain, 5); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	meta := m.Data[cfg.MetadataOffset:]
+	if !bytes.Equal(meta[:5], []byte("ISEC\x01")) || binary.BigEndian.Uint32(meta[5:]) != 512 || binary.BigEndian.Uint64(meta[9:]) != 8 {
+		t.Fatalf("header %x", meta[:integrity.MetadataHeaderSize])
+	}
+	if int64(len(meta)) != integrity.MetadataSize(8) {
+		t.Fatalf("metadata region of %d bytes", len(meta))
+	}
+
+	extract := hmac.New(sha256.New, meta[17:33])
+	extract.Write(key)
+	expand := hmac.New(sha256.New, extract.Sum(nil))
+	expand.Write([]byte("integrity sector key\x01"))
+	block, _ := goaes.NewCipher(expand.Sum(nil))
+	gcm, _ := gocipher.NewGCM(block)
+	nonce := make([]byte, 12)
+	binary.BigEndian.PutUint64(nonce, 5)
+	binary.BigEndian.PutUint32(nonce[8:], 3)
+	want := gcm.Seal(nil, nonce, plain, nil)
+
+	entry := meta[integrity.MetadataHeaderSize+5*integrity.MetadataEntrySize:][:integrity.MetadataEntrySize]
+	if binary.BigEndian.Uint32(entry) != 3 || !bytes.Equal(entry[4:], want[512:]) || !bytes.Equal(m.Data[5*512:6*512], want[:512]) {
+		t.Errorf("sector 5 stored as %x and %x", entry, m.Data[5*512:6*512])
+	}
+}
+
+// TestStoreTampering checks reads fail on modified, moved and rolled
+// back sectors.
+func TestStoreTampering(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	key := make([]byte, 16)
+	cfg := config(512, 4)
+	entry := func(sector int64) int64 {
+		return cfg.MetadataOffset + integrity.MetadataHeaderSize + sector*integrity.MetadataEntrySize
+	}
+	setup := func() (*backingtest.Mem, *integrity.Store) {
+		m := new(backingtest.Mem)
+		s, err := integrity.Format(m, key, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p := make([]byte, 4*512)
+		for i := range p {
+			p[i] = byte(i / 512)
+		}
+		if err := s.WriteSectors(p, 0); err != nil {
+			t.Fatal(err)
+		}
+		return m, s
+	}
+	p := make([]byte, 512)
+
+	for _, c := range []struct {
+		name   string
+		tamper func(m *backingtest.Mem)
+	}{
+		{"data", func(m *backingtest.Mem) { m.Data[512+100] ^= 1 }},
+		{"tag", func(m *backingtest.Mem) { m.Data[entry(1)+10] ^= 1 }},
+		{"generation", func(m *backingtest.Mem) { m.Data[entry(1)+3]++ }},
+		{"erased", func(m *backingtest.Mem) { copy(m.Data[entry(1):], make([]byte, integrity.MetadataEntrySize)) }},
+		{"moved", func(m *backingtest.Mem) {
+			copy(m.Data[512:1024], m.Data[2*512:3*512])
+			copy(m.Data[entry(1):entry(2)], m.Data[entry(2):entry(3)])
+		}},
+	} {
+		m, s := setup()
+		c.tamper(m)
+		if err := s.ReadSectors(p, 1); err != integrity.ErrAuth {
+			t.Errorf("%s: ReadSectors returned %v", c.name, err)
+		}
+		if err := s.ReadSectors(p, 0); err != nil {
+			t.Errorf("%s: ReadSectors of another sector returned %v", c.name, err)
+		}
+
+		// Without the generations in memory, the tag catches it, but
+		// for an erased sector, which only the digest catches
+		if c.name == "erased" {
+			cfg := cfg
+			cfg.Digest = s.Digest()
+			if _, err := integrity.Open(m, key, cfg); err != integrity.ErrAuth {
+				t.Errorf("%s: Open returned %v", c.name, err)
+			}
+			continue
+		}
+		s, err := integrity.Open(m, key, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.ReadSectors(p, 1); err != integrity.ErrAuth {
+			t.Errorf("%s: ReadSectors after Open returned %v", c.name, err)
+		}
+	}
+
+	// Roll back sector 1 and its metadata
+	m, s := setup()
+	old := append([]byte(nil), m.Data...)
+	digest := s.Digest()
+	if err := s.WriteSectors(make([]byte, 512), 1); err != nil {
+		t.Fatal(err)
+	}
+	copy(m.Data[512:1024], old[512:1024])
+	copy(m.Data[entry(1):entry(2)], old[entry(1):entry(2)])
+	if err := s.ReadSectors(p, 1); err != integrity.ErrAuth {
+		t.Errorf("rollback: ReadSectors returned %v", err)
+	}
+
+	cfg.Digest = s.Digest()
+	if _, err := integrity.Open(m, key, cfg); err != integrity.ErrAuth {
+		t.Errorf("rollback: Open with the last digest returned %v", err)
+	}
+	cfg.Digest = digest
+	if _, err := integrity.Open(m, key, cfg); err != nil {
+		t.Errorf("rollback: Open with the old digest returned %v", err)
+	}
+}
+
+// crashBacking is a backing store with a volatile write cache: writes
+// reach durable only on Sync, and crash persists the cached writes that
+// keep selects, in any order a disk could have.
+type crashBacking struct {
+	backingtest.Mem
+	durable backingtest.Mem
+	pending []cachedWrite
+}
+
+type cachedWrite struct {
+	p   []byte
+	off int64
+}
+
+func (c *crashBacking) WriteAt(p []byte, off int64) (int, error) {
+	c.pending = append(c.pending, cachedWrite{append([]byte(nil), p...), off})
+	return c.Mem.WriteAt(p, off)
+}
+
+func (c *crashBacking) Sync() error {
+	for _, w := range c.pending {
+		c.durable.WriteAt(w.p, w.off)
+	}
+	c.pending = nil
+	return nil
+}
+
+func (c *crashBacking) crash(keep func(off int64) bool) {
+	for _, w := range c.pending {
+		if keep(w.off) {
+			c.durable.WriteAt(w.p, w.off)
+		}
+	}
+	c.pending = nil
+	c.Mem.Data = append([]byte(nil), c.durable.Data...)
+}
+
+// TestStoreCrash loses the metadata of a write whose data reached the
+// disk; writing the sector again must not reuse its nonce.
+func TestStoreCrash(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	key := make([]byte, 16)
+	cfg := config(512, 4)
+	c := new(crashBacking)
+	s, err := integrity.Format(c, key, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	a, b := bytes.Repeat([]byte{'a'}, 512), bytes.Repeat([]byte{'b'}, 512)
+	if err := s.WriteSectors(a, 1); err != nil {
+		t.Fatal(err)
+	}
+	c.crash(func(off int64) bool { return off < cfg.MetadataOffset })
+	ctA := append([]byte(nil), c.durable.Data[512:1024]...)
+
+	s, err = integrity.Open(c, key, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.WriteSectors(b, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	ctB := c.durable.Data[512:1024]
+
+	// Under one nonce the ciphertexts differ as the plaintexts do
+	same := true
+	for i := range ctA {
+		if ctA[i]^ctB[i] != a[i]^b[i] {
+			same = false
+			break
+		}
+	}
+	if same {
+		t.Error("rewrite after a lost metadata write reused the nonce")
+	}
+
+	got := make([]byte, 512)
+	if err := s.ReadSectors(got, 1); err != nil || !bytes.Equal(got, b) {
+		t.Errorf("sector 1 reads %q, %v", got[:8], err)
+	}
+}
+
+func TestStoreErrors(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	m := new(backingtest.Mem)
+	for _, c := range []struct {
+		keySize int
+		cfg     integrity.Config
+	}{
+		{24, config(512, 4)},
+		{16, config(512, 0)},
+		{16, config(256, 4)},
+		{16, config(1536, 4)},
+		{16, config(8192, 4)},
+		{16, integrity.Config{SectorSize: 512, Sectors: 4, MetadataOffset: 1024}},
+		{16, integrity.Config{SectorSize: 512, Sectors: 4, DataOffset: -512, MetadataOffset: 4096}},
+	} {
+		if _, err := integrity.Format(m, make([]byte, c.keySize), c.cfg); err == nil {
+			t.Errorf("Format accepted a %d byte key with %+v", c.keySize, c.cfg)
+		}
+	}
+
+	if _, err := integrity.Open(new(backingtest.Mem), make([]byte, 16), config(512, 4)); err == nil {
+		t.Error("Open accepted an empty backing store")
+	}
+	s, err := integrity.Format(m, make([]byte, 16), config(512, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := integrity.Open(m, make([]byte, 16), config(512, 8)); err == nil {
+		t.Error("Open accepted another number of sectors")
+	}
+
+	p := make([]byte, 1024)
+	if err := s.ReadSectors(p[:100], 0); err == nil {
+		t.Error("ReadSectors accepted a partial sector")
+	}
+	if err := s.WriteSectors(p, 3); err == nil {
+		t.Error("WriteSectors accepted a sector past the end")
+	}
+	if err := s.WriteSectors(p, -1); err == nil {
+		t.Error("WriteSectors accepted a negative sector")
+	}
+}
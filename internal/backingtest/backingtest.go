@@ -0,0 +1,70 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package backingtest provides the backing stores that the tests of the
+// sector packages run against.
+package backingtest
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Mem is a backing store in memory that grows on writes. Tests may
+// inspect and modify Data directly.
+type Mem struct {
+	Data []byte
+}
+
+func (m *Mem) ReadAt(p []byte, off int64) (int, error) {
+	if off >= int64(len(m.Data)) {
+		return 0, io.EOF
+	}
+	n := copy(p, m.Data[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (m *Mem) WriteAt(p []byte, off int64) (int, error) {
+	if end := off + int64(len(p)); end > int64(len(m.Data)) {
+		m.Data = append(m.Data, make([]byte, end-int64(len(m.Data)))...)
+	}
+	return copy(m.Data[off:], p), nil
+}
+
+// TempFile returns an empty file in a temporary directory that is
+// closed and removed when the test ends.
+func TempFile(t testing.TB) *os.File {
+	t.Helper()
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "disk"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.Close() })
+
+	return f
+}
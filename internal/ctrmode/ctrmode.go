@@ -0,0 +1,99 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package ctrmode runs counter mode over a block encryption function. It
+// is the key stream of CCM, EAX, SIV and AES-GCM-SIV, which differ only in
+// how the counter block advances.
+package ctrmode
+
+import (
+	"errors"
+)
+
+// BlockSize is the AES block size, the length of a counter block
+const BlockSize = 16
+
+// chunkSize is how much key stream is handed to encryptBlocks at a time
+const chunkSize = 4096
+
+// IncrementBE increments the counter block ctr as a big-endian integer,
+// wrapping around. It is the counter of CTR (SP 800-38A), CCM, EAX and
+// SIV.
+func IncrementBE(ctr []byte) {
+	for i := len(ctr) - 1; i >= 0; i-- {
+		ctr[i]++
+		if ctr[i] != 0 {
+			break
+		}
+	}
+}
+
+// IncrementLE32 increments the first four bytes of the counter block ctr
+// as a little-endian integer, wrapping around. It is the counter of
+// AES-GCM-SIV (RFC 8452).
+func IncrementLE32(ctr []byte) {
+	for i := 0; i < 4; i++ {
+		ctr[i]++
+		if ctr[i] != 0 {
+			break
+		}
+	}
+}
+
+// XORKeyStream XORs src with the counter mode key stream into dst: the
+// encryption of the counter block ctr, which inc advances after each
+// block. encryptBlocks encrypts a whole number of blocks independently;
+// the counter blocks of a chunk go to it in one call. On return ctr holds
+// the counter block after the last one used. dst and src must overlap
+// entirely or not at all.
+func XORKeyStream(encryptBlocks func(dst, src []byte) error, dst, src, ctr []byte, inc func([]byte)) error {
+	if len(ctr) != BlockSize {
+		return errors.New("ctrmode: counter block must be 16 bytes")
+	}
+	if len(dst) < len(src) {
+		return errors.New("ctrmode: destination buffer too small")
+	}
+
+	var counters, ks [chunkSize]byte
+	for len(src) > 0 {
+		n := len(src)
+		if n > chunkSize {
+			n = chunkSize
+		}
+		blocks := (n + BlockSize - 1) / BlockSize * BlockSize
+
+		for i := 0; i < blocks; i += BlockSize {
+			copy(counters[i:], ctr)
+			inc(ctr)
+		}
+		if err := encryptBlocks(ks[:blocks], counters[:blocks]); err != nil {
+			return err
+		}
+		for i := 0; i < n; i++ {
+			dst[i] = src[i] ^ ks[i]
+		}
+
+		dst, src = dst[n:], src[n:]
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ctrmode_test
+
+import (
+	"bytes"
+	stdaes "crypto/aes"
+	stdcipher "crypto/cipher"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/surendarchandra/crypto/internal/ctrmode"
+)
+
+// ecb encrypts each block of src independently with b
+func ecb(b stdcipher.Block) func(dst, src []byte) error {
+	return func(dst, src []byte) error {
+		for i := 0; i < len(src); i += ctrmode.BlockSize {
+			b.Encrypt(dst[i:], src[i:])
+		}
+		return nil
+	}
+}
+
+func TestXORKeyStream(t *testing.T) {
+	// SP 800-38A F.5.1 CTR-AES128.Encrypt
+	key, _ := hex.DecodeString("2b7e151628aed2a6abf7158809cf4f3c")
+	iv, _ := hex.DecodeString("f0f1f2f3f4f5f6f7f8f9fafbfcfdfeff")
+	plaintext, _ := hex.DecodeString("6bc1bee22e409f96e93d7e117393172aae2d8a571e03ac9c9eb76fac45af8e51" +
+		"30c81c46a35ce411e5fbc1191a0a52eff69f2445df4f9b17ad2b417be66c3710")
+	expected, _ := hex.DecodeString("874d6191b620e3261bef6864990db6ce9806f66b7970fdff8617187bb9fffdff" +
+		"5ae4df3edbd5d35e5b4f09020db03eab1e031dda2fbe03d1792170a0f3009cee")
+
+	block, err := stdaes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctr := append([]byte(nil), iv...)
+	out := make([]byte, len(plaintext))
+	if err := ctrmode.XORKeyStream(ecb(block), out, plaintext, ctr, ctrmode.IncrementBE); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out, expected) {
+		t.Errorf("got %x, want %x", out, expected)
+	}
+	if want := "f0f1f2f3f4f5f6f7f8f9fafbfcfdff03"; hex.EncodeToString(ctr) != want {
+		t.Errorf("counter after use %x, want %s", ctr, want)
+	}
+
+	// Several chunks with a ragged tail, in place, against the standard
+	// library
+	long := make([]byte, 3*4096+37)
+	for i := range long {
+		long[i] = byte(i)
+	}
+	want := make([]byte, len(long))
+	stdcipher.NewCTR(block, iv).XORKeyStream(want, long)
+
+	copy(ctr, iv)
+	if err := ctrmode.XORKeyStream(ecb(block), long, long, ctr, ctrmode.IncrementBE); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(long, want) {
+		t.Error("long in-place key stream mismatch")
+	}
+}
+
+func TestIncrement(t *testing.T) {
+	ctr, _ := hex.DecodeString("00ffffffffffffffffffffffffffffff")
+	ctrmode.IncrementBE(ctr)
+	if want := "01000000000000000000000000000000"; hex.EncodeToString(ctr) != want {
+		t.Errorf("IncrementBE: got %x, want %s", ctr, want)
+	}
+
+	ctr, _ = hex.DecodeString("ffffffff0102030405060708090a0b0c")
+	ctrmode.IncrementLE32(ctr)
+	if want := "000000000102030405060708090a0b0c"; hex.EncodeToString(ctr) != want {
+		t.Errorf("IncrementLE32 wrap: got %x, want %s", ctr, want)
+	}
+	ctr[0] = 0xff
+	ctrmode.IncrementLE32(ctr)
+	if want := "000100000102030405060708090a0b0c"; hex.EncodeToString(ctr) != want {
+		t.Errorf("IncrementLE32 carry: got %x, want %s", ctr, want)
+	}
+}
+
+func TestXORKeyStreamErrors(t *testing.T) {
+	block, err := stdaes.NewCipher(make([]byte, 16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ctrmode.XORKeyStream(ecb(block), make([]byte, 16), make([]byte, 16), make([]byte, 8), ctrmode.IncrementBE); err == nil {
+		t.Error("short counter block accepted")
+	}
+	if err := ctrmode.XORKeyStream(ecb(block), make([]byte, 8), make([]byte, 16), make([]byte, 16), ctrmode.IncrementBE); err == nil {
+		t.Error("short destination accepted")
+	}
+
+	fail := errors.New("no ECB")
+	broken := func(dst, src []byte) error { return fail }
+	if err := ctrmode.XORKeyStream(broken, make([]byte, 16), make([]byte, 16), make([]byte, 16), ctrmode.IncrementBE); err != fail {
+		t.Errorf("got %v, want the block error", err)
+	}
+}
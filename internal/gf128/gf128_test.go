@@ -0,0 +1,46 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package gf128_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/surendarchandra/crypto/internal/gf128"
+)
+
+// The AES-128 subkeys of RFC 4493 section 4: K1 and K2 are L doubled
+// once and twice; the second doubling carries out of the top bit.
+func TestDouble(t *testing.T) {
+	var l [16]byte
+	hex.Decode(l[:], []byte("7df76b0c1ab899b33e42f047b91b546f"))
+
+	k1 := gf128.Double(l)
+	if got, want := hex.EncodeToString(k1[:]), "fbeed618357133667c85e08f7236a8de"; got != want {
+		t.Errorf("K1: got %s, want %s", got, want)
+	}
+	k2 := gf128.Double(k1)
+	if got, want := hex.EncodeToString(k2[:]), "f7ddac306ae266ccf90bc11ee46d513b"; got != want {
+		t.Errorf("K2: got %s, want %s", got, want)
+	}
+}
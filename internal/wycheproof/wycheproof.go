@@ -0,0 +1,351 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package wycheproof loads the vendored Project Wycheproof test vectors
+// and checks implementations against them. It is only meant to be used
+// from tests.
+package wycheproof
+
+import (
+	"bytes"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/surendarchandra/crypto/cipher"
+)
+
+//go:embed testdata/*.json
+var vectors embed.FS
+
+// HexBytes is a byte string encoded as hex in the vector files.
+type HexBytes []byte
+
+// UnmarshalJSON decodes a hex string.
+func (h *HexBytes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	*h = b
+
+	return nil
+}
+
+// Test holds the fields shared by all vector types.
+type Test struct {
+	TcID    int      `json:"tcId"`
+	Comment string   `json:"comment"`
+	Flags   []string `json:"flags"`
+	Result  string   `json:"result"`
+}
+
+// AEADTest is a single vector of the AeadTest type.
+type AEADTest struct {
+	Test
+	Key HexBytes `json:"key"`
+	IV  HexBytes `json:"iv"`
+	AAD HexBytes `json:"aad"`
+	Msg HexBytes `json:"msg"`
+	Ct  HexBytes `json:"ct"`
+	Tag HexBytes `json:"tag"`
+}
+
+// AEADFile is a vector file with AeadTest groups. Sizes are in bits.
+type AEADFile struct {
+	Algorithm  string `json:"algorithm"`
+	TestGroups []struct {
+		KeySize int        `json:"keySize"`
+		IVSize  int        `json:"ivSize"`
+		TagSize int        `json:"tagSize"`
+		Tests   []AEADTest `json:"tests"`
+	} `json:"testGroups"`
+}
+
+// DaeadTest is a single vector of the DaeadTest type, deterministic
+// AEAD such as AES-SIV. Ct is the complete output, tag included.
+type DaeadTest struct {
+	Test
+	Key HexBytes `json:"key"`
+	AAD HexBytes `json:"aad"`
+	Msg HexBytes `json:"msg"`
+	Ct  HexBytes `json:"ct"`
+}
+
+// DaeadFile is a vector file with DaeadTest groups. Sizes are in bits.
+type DaeadFile struct {
+	Algorithm  string `json:"algorithm"`
+	TestGroups []struct {
+		KeySize int         `json:"keySize"`
+		Tests   []DaeadTest `json:"tests"`
+	} `json:"testGroups"`
+}
+
+// MacTest is a single vector of the MacTest type.
+type MacTest struct {
+	Test
+	Key HexBytes `json:"key"`
+	Msg HexBytes `json:"msg"`
+	Tag HexBytes `json:"tag"`
+}
+
+// MacFile is a vector file with MacTest groups. Sizes are in bits.
+type MacFile struct {
+	Algorithm  string `json:"algorithm"`
+	TestGroups []struct {
+		KeySize int       `json:"keySize"`
+		TagSize int       `json:"tagSize"`
+		Tests   []MacTest `json:"tests"`
+	} `json:"testGroups"`
+}
+
+// MacWithIVTest is a single vector of the MacWithIvTest type, MACs
+// such as GMAC that take a nonce.
+type MacWithIVTest struct {
+	Test
+	Key HexBytes `json:"key"`
+	IV  HexBytes `json:"iv"`
+	Msg HexBytes `json:"msg"`
+	Tag HexBytes `json:"tag"`
+}
+
+// MacWithIVFile is a vector file with MacWithIVTest groups. Sizes are in
+// bits.
+type MacWithIVFile struct {
+	Algorithm  string `json:"algorithm"`
+	TestGroups []struct {
+		KeySize int             `json:"keySize"`
+		IVSize  int             `json:"ivSize"`
+		TagSize int             `json:"tagSize"`
+		Tests   []MacWithIVTest `json:"tests"`
+	} `json:"testGroups"`
+}
+
+// KeywrapTest is a single vector of the KeywrapTest type.
+type KeywrapTest struct {
+	Test
+	Key HexBytes `json:"key"`
+	Msg HexBytes `json:"msg"`
+	Ct  HexBytes `json:"ct"`
+}
+
+// KeywrapFile is a vector file with KeywrapTest groups. Sizes are in
+// bits.
+type KeywrapFile struct {
+	Algorithm  string `json:"algorithm"`
+	TestGroups []struct {
+		KeySize int           `json:"keySize"`
+		Tests   []KeywrapTest `json:"tests"`
+	} `json:"testGroups"`
+}
+
+// IndCpaTest is a single vector of the IndCpaTest type, used for
+// unauthenticated modes such as CBC and XTS.
+type IndCpaTest struct {
+	Test
+	Key HexBytes `json:"key"`
+	IV  HexBytes `json:"iv"`
+	Msg HexBytes `json:"msg"`
+	Ct  HexBytes `json:"ct"`
+}
+
+// IndCpaFile is a vector file with IndCpaTest groups. Sizes are in bits.
+type IndCpaFile struct {
+	Algorithm  string `json:"algorithm"`
+	TestGroups []struct {
+		KeySize int          `json:"keySize"`
+		IVSize  int          `json:"ivSize"`
+		Tests   []IndCpaTest `json:"tests"`
+	} `json:"testGroups"`
+}
+
+// Load decodes the named vector file, e.g. "aes_gcm_test.json", into v.
+func Load(t testing.TB, name string, v interface{}) {
+	data, err := vectors.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+}
+
+// Tally checks outcomes against the expected results and counts them
+// per flag, so that a run shows which classes of vectors were exercised
+// and which were skipped as unsupported.
+type Tally struct {
+	t      testing.TB
+	counts map[string]*count
+}
+
+type count struct {
+	passed, failed, skipped int
+}
+
+// NewTally returns an empty Tally reporting failures to t.
+func NewTally(t testing.TB) *Tally {
+	return &Tally{t: t, counts: make(map[string]*count)}
+}
+
+func (w *Tally) add(flags []string, f func(c *count)) {
+	if len(flags) == 0 {
+		flags = []string{"(none)"}
+	}
+	for _, flag := range flags {
+		c, ok := w.counts[flag]
+		if !ok {
+			c = new(count)
+			w.counts[flag] = c
+		}
+		f(c)
+	}
+}
+
+// Skip records a vector whose parameters the implementation does not
+// support.
+func (w *Tally) Skip(tc Test) {
+	w.add(tc.Flags, func(c *count) { c.skipped++ })
+}
+
+// Check records the outcome of running tc. ok reports whether the
+// implementation accepted the input and match whether its output equals
+// the expected one. Valid vectors must be accepted and match, invalid
+// ones must be rejected and acceptable ones may go either way but must
+// match if accepted.
+func (w *Tally) Check(tc Test, ok, match bool) {
+	w.t.Helper()
+
+	var pass bool
+	switch tc.Result {
+	case "valid":
+		pass = ok && match
+	case "invalid":
+		pass = !ok
+	case "acceptable":
+		pass = !ok || match
+	default:
+		w.t.Fatalf("tcId %d: unknown result %q", tc.TcID, tc.Result)
+	}
+
+	if pass {
+		w.add(tc.Flags, func(c *count) { c.passed++ })
+		return
+	}
+
+	w.add(tc.Flags, func(c *count) { c.failed++ })
+	w.t.Errorf("tcId %d (%s) %v: accepted=%v matched=%v, want %s", tc.TcID, tc.Comment, tc.Flags, ok, match, tc.Result)
+}
+
+// Report logs the per flag counts.
+func (w *Tally) Report() {
+	w.t.Helper()
+
+	flags := make([]string, 0, len(w.counts))
+	for flag := range w.counts {
+		flags = append(flags, flag)
+	}
+	sort.Strings(flags)
+
+	for _, flag := range flags {
+		c := w.counts[flag]
+		w.t.Logf("%-24s passed %4d  failed %4d  skipped %4d", flag, c.passed, c.failed, c.skipped)
+	}
+}
+
+// RunAEAD runs every vector of the named AeadTest file. newAEAD returns
+// the implementation for a key and the group's nonce and tag sizes in
+// bytes, or an error if those parameters are not supported. A vector
+// whose parameters are rejected, by newAEAD or by a panic in Open, is
+// skipped whatever its result: an invalid vector only passes when Open
+// returns an error for it.
+func RunAEAD(t testing.TB, name string, newAEAD func(key []byte, nonceSize, tagSize int) (cipher.AEAD, error)) {
+	t.Helper()
+
+	var file AEADFile
+	Load(t, name, &file)
+
+	tally := NewTally(t)
+	defer tally.Report()
+
+	for _, group := range file.TestGroups {
+		for _, tc := range group.Tests {
+			aead, err := newAEAD(tc.Key, group.IVSize/8, group.TagSize/8)
+			if err != nil {
+				tally.Skip(tc.Test)
+				continue
+			}
+
+			want := append(append([]byte(nil), tc.Ct...), tc.Tag...)
+			match := true
+			if tc.Result == "valid" {
+				got, err := seal(aead, tc.IV, tc.Msg, tc.AAD)
+				match = err == nil && bytes.Equal(got, want)
+			}
+
+			got, err := open(aead, tc.IV, want, tc.AAD)
+			if _, ok := err.(panicError); ok && tc.Result != "valid" {
+				tally.Skip(tc.Test)
+				continue
+			}
+			tally.Check(tc.Test, err == nil, match && bytes.Equal(got, tc.Msg))
+		}
+	}
+}
+
+// panicError is a panic of Seal or Open, which reject parameters that
+// way, turned into an error.
+type panicError struct {
+	v interface{}
+}
+
+func (e panicError) Error() string {
+	return fmt.Sprint(e.v)
+}
+
+// seal and open turn a panic on bad parameters into an error.
+func seal(aead cipher.AEAD, nonce, plaintext, additionalData []byte) (out []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicError{r}
+		}
+	}()
+
+	return aead.Seal(nil, nonce, plaintext, additionalData), nil
+}
+
+func open(aead cipher.AEAD, nonce, ciphertext, additionalData []byte) (out []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicError{r}
+		}
+	}()
+
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
@@ -0,0 +1,221 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package keywrap implements the AES Key Wrap (KW, RFC 3394) and Key Wrap
+// with Padding (KWP, RFC 5649) algorithms of SP 800-38F, for wrapping
+// data keys under a key encryption key. The KEK is a cipher.Block from
+// aes.NewCipher with a 16, 24 or 32 byte key.
+//
+// Unwrapping checks the integrity value in constant time and returns
+// ErrUnwrap, whatever part of the check failed.
+package keywrap
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+
+	"github.com/surendarchandra/crypto/cipher"
+)
+
+// ErrUnwrap is returned when a wrapped key fails the integrity check.
+var ErrUnwrap = errors.New("keywrap: integrity check failed")
+
+var (
+	// defaultIV is the KW integrity check value (RFC 3394 section 2.2.3.1)
+	defaultIV = []byte{0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6}
+
+	// aivPrefix starts the KWP alternative initial value, followed by the
+	// 32-bit message length (RFC 5649 section 3)
+	aivPrefix = []byte{0xa6, 0x59, 0x59, 0xa6}
+)
+
+// wrap is the wrapping function W of SP 800-38F section 6.1 on the
+// semiblocks r, in place, starting from a. It returns the final A.
+func wrap(kek cipher.Block, a [8]byte, r []byte) ([8]byte, error) {
+	var b [16]byte
+
+	n := len(r) / 8
+	for j := 0; j < 6; j++ {
+		for i := 0; i < n; i++ {
+			copy(b[:8], a[:])
+			copy(b[8:], r[8*i:])
+			if err := cipher.EncryptBlock(kek, b[:], b[:]); err != nil {
+				return a, err
+			}
+			binary.BigEndian.PutUint64(a[:], binary.BigEndian.Uint64(b[:8])^uint64(n*j+i+1))
+			copy(r[8*i:], b[8:])
+		}
+	}
+
+	return a, nil
+}
+
+// unwrap is the inverse W^-1.
+func unwrap(kek cipher.Block, a [8]byte, r []byte) ([8]byte, error) {
+	var b [16]byte
+
+	n := len(r) / 8
+	for j := 5; j >= 0; j-- {
+		for i := n - 1; i >= 0; i-- {
+			binary.BigEndian.PutUint64(b[:8], binary.BigEndian.Uint64(a[:])^uint64(n*j+i+1))
+			copy(b[8:], r[8*i:])
+			if err := cipher.DecryptBlock(kek, b[:], b[:]); err != nil {
+				return a, err
+			}
+			copy(a[:], b[:8])
+			copy(r[8*i:], b[8:])
+		}
+	}
+
+	return a, nil
+}
+
+// Wrap wraps plaintext, a multiple of 8 bytes and at least 16, with KW.
+// The result is 8 bytes longer.
+func Wrap(kek cipher.Block, plaintext []byte) ([]byte, error) {
+	if len(plaintext) < 16 || len(plaintext)%8 != 0 {
+		return nil, errors.New("keywrap: KW input must be a multiple of 8 bytes and at least 16")
+	}
+
+	out := make([]byte, 8+len(plaintext))
+	copy(out[8:], plaintext)
+
+	var a [8]byte
+	copy(a[:], defaultIV)
+	a, err := wrap(kek, a, out[8:])
+	if err != nil {
+		return nil, err
+	}
+	copy(out, a[:])
+
+	return out, nil
+}
+
+// Unwrap unwraps a KW ciphertext.
+func Unwrap(kek cipher.Block, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < 24 || len(ciphertext)%8 != 0 {
+		return nil, ErrUnwrap
+	}
+
+	out := make([]byte, len(ciphertext)-8)
+	copy(out, ciphertext[8:])
+
+	var a [8]byte
+	copy(a[:], ciphertext)
+	a, err := unwrap(kek, a, out)
+	if err != nil {
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare(a[:], defaultIV) != 1 {
+		for i := range out {
+			out[i] = 0
+		}
+		return nil, ErrUnwrap
+	}
+
+	return out, nil
+}
+
+// WrapPad wraps plaintext of 1 to 2^32 - 1 bytes with KWP. The result is
+// the plaintext zero padded to a multiple of 8 bytes, plus 8.
+func WrapPad(kek cipher.Block, plaintext []byte) ([]byte, error) {
+	if len(plaintext) < 1 || uint64(len(plaintext)) > 1<<32-1 {
+		return nil, errors.New("keywrap: KWP input must be 1 to 2^32 - 1 bytes")
+	}
+
+	var a [8]byte
+	copy(a[:], aivPrefix)
+	binary.BigEndian.PutUint32(a[4:], uint32(len(plaintext)))
+
+	out := make([]byte, 8+(len(plaintext)+7)/8*8)
+	copy(out[8:], plaintext)
+
+	// A single semiblock is encrypted as one block with the AIV
+	if len(out) == 16 {
+		copy(out, a[:])
+		if err := cipher.EncryptBlock(kek, out, out); err != nil {
+			return nil, err
+		}
+		return out, nil
+	}
+
+	a, err := wrap(kek, a, out[8:])
+	if err != nil {
+		return nil, err
+	}
+	copy(out, a[:])
+
+	return out, nil
+}
+
+// UnwrapPad unwraps a KWP ciphertext.
+func UnwrapPad(kek cipher.Block, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < 16 || len(ciphertext)%8 != 0 {
+		return nil, ErrUnwrap
+	}
+
+	var a [8]byte
+	var out []byte
+	if len(ciphertext) == 16 {
+		var b [16]byte
+		copy(b[:], ciphertext)
+		if err := cipher.DecryptBlock(kek, b[:], b[:]); err != nil {
+			return nil, err
+		}
+		copy(a[:], b[:8])
+		out = b[8:]
+	} else {
+		out = make([]byte, len(ciphertext)-8)
+		copy(out, ciphertext[8:])
+		copy(a[:], ciphertext)
+
+		var err error
+		if a, err = unwrap(kek, a, out); err != nil {
+			return nil, err
+		}
+	}
+
+	// The length must leave less than a semiblock of padding, and the
+	// padding must be zero
+	mli := uint64(binary.BigEndian.Uint32(a[4:]))
+	lo, hi := uint64(len(out)-7), uint64(len(out))
+	ok := subtle.ConstantTimeCompare(a[:4], aivPrefix)
+	ok &= int(((mli-lo)|(hi-mli))>>63) ^ 1
+
+	var pad byte
+	for i := range out {
+		inPad := (uint64(i)-mli)>>63 ^ 1
+		pad |= out[i] & byte(-inPad)
+	}
+	ok &= subtle.ConstantTimeByteEq(pad, 0)
+
+	if ok != 1 {
+		for i := range out {
+			out[i] = 0
+		}
+		return nil, ErrUnwrap
+	}
+
+	return out[:mli], nil
+}
@@ -0,0 +1,204 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package keywrap_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/keywrap"
+)
+
+func fromHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+var keywrapTests = []struct {
+	kek, plaintext, ciphertext string
+	pad                        bool
+}{
+	// RFC 3394 section 4
+	{
+		"000102030405060708090a0b0c0d0e0f",
+		"00112233445566778899aabbccddeeff",
+		"1fa68b0a8112b447aef34bd8fb5a7b829d3e862371d2cfe5",
+		false,
+	},
+	{
+		"000102030405060708090a0b0c0d0e0f1011121314151617",
+		"00112233445566778899aabbccddeeff",
+		"96778b25ae6ca435f92b5b97c050aed2468ab8a17ad84e5d",
+		false,
+	},
+	{
+		"000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+		"00112233445566778899aabbccddeeff",
+		"64e8c3f9ce0f5ba263e9777905818a2a93c8191e7d6e8ae7",
+		false,
+	},
+	{
+		"000102030405060708090a0b0c0d0e0f1011121314151617",
+		"00112233445566778899aabbccddeeff0001020304050607",
+		"031d33264e15d33268f24ec260743edce1c6c7ddee725a936ba814915c6762d2",
+		false,
+	},
+	{
+		"000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+		"00112233445566778899aabbccddeeff0001020304050607",
+		"a8f9bc1612c68b3ff6e6f4fbe30e71e4769c8b80a32cb8958cd5d17d6b254da1",
+		false,
+	},
+	{
+		"000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+		"00112233445566778899aabbccddeeff000102030405060708090a0b0c0d0e0f",
+		"28c9f404c4b810f4cbccb35cfb87f8263f5786e2d80ed326cbc7f0e71a99f43bfb988b9b7a02dd21",
+		false,
+	},
+	// RFC 5649 section 6
+	{
+		"5840df6e29b02af1ab493b705bf16ea1ae8338f4dcc176a8",
+		"c37b7e6492584340bed12207808941155068f738",
+		"138bdeaa9b8fa7fc61f97742e72248ee5ae6ae5360d1ae6a5f54f373fa543b6a",
+		true,
+	},
+	{
+		"5840df6e29b02af1ab493b705bf16ea1ae8338f4dcc176a8",
+		"466f7250617369",
+		"afbeb0f07dfbf5419200f2ccb50bb24f",
+		true,
+	},
+	// SP 800-38F, KW_AE_128 and KWP_AE_128 count 0
+	{
+		"7575da3a93607cc2bfd8cec7aadfd9a6",
+		"42136d3c384a3eeac95a066fd28fed3f",
+		"031f6bd7e61e643df68594816f64caa3f56fabea2548f5fb",
+		false,
+	},
+	{
+		"6decf10a1caf8e3b80c7a4be8c9c84e8",
+		"49",
+		"01a7d657fc4a5b216f261cca4d052c2b",
+		true,
+	},
+}
+
+func TestKeyWrap(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	for i, test := range keywrapTests {
+		kek, err := aes.NewCipher(fromHex(test.kek))
+		if err != nil {
+			t.Fatal(err)
+		}
+		plaintext := fromHex(test.plaintext)
+
+		wrap, unwrap := keywrap.Wrap, keywrap.Unwrap
+		if test.pad {
+			wrap, unwrap = keywrap.WrapPad, keywrap.UnwrapPad
+		}
+
+		ct, err := wrap(kek, plaintext)
+		if err != nil {
+			t.Errorf("#%d: %v", i, err)
+			continue
+		}
+		if got := hex.EncodeToString(ct); got != test.ciphertext {
+			t.Errorf("#%d: got %s, want %s", i, got, test.ciphertext)
+			continue
+		}
+
+		pt, err := unwrap(kek, ct)
+		if err != nil {
+			t.Errorf("#%d: unwrap failed: %v", i, err)
+		} else if !bytes.Equal(pt, plaintext) {
+			t.Errorf("#%d: got %x, want %x", i, pt, plaintext)
+		}
+
+		ct[len(ct)-1] ^= 1
+		if _, err := unwrap(kek, ct); err != keywrap.ErrUnwrap {
+			t.Errorf("#%d: modified ciphertext gave %v", i, err)
+		}
+	}
+}
+
+func TestKeyWrapLengths(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	kek, err := aes.NewCipher(make([]byte, 16))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, n := range []int{0, 8, 15, 17} {
+		if _, err := keywrap.Wrap(kek, make([]byte, n)); err == nil {
+			t.Errorf("KW wrapped %d bytes", n)
+		}
+	}
+	if _, err := keywrap.WrapPad(kek, nil); err == nil {
+		t.Error("KWP wrapped an empty key")
+	}
+	for _, n := range []int{0, 8, 16, 23} {
+		if _, err := keywrap.Unwrap(kek, make([]byte, n)); err != keywrap.ErrUnwrap {
+			t.Errorf("KW unwrapped %d bytes: %v", n, err)
+		}
+	}
+	for _, n := range []int{0, 8, 15} {
+		if _, err := keywrap.UnwrapPad(kek, make([]byte, n)); err != keywrap.ErrUnwrap {
+			t.Errorf("KWP unwrapped %d bytes: %v", n, err)
+		}
+	}
+
+	// Every KWP length around the semiblock boundaries round trips
+	for n := 1; n <= 40; n++ {
+		key := bytes.Repeat([]byte{0xff}, n)
+		ct, err := keywrap.WrapPad(kek, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ct) != 8+(n+7)/8*8 {
+			t.Errorf("KWP %d bytes: wrapped length %d", n, len(ct))
+		}
+		if pt, err := keywrap.UnwrapPad(kek, ct); err != nil || !bytes.Equal(pt, key) {
+			t.Errorf("KWP %d bytes: round trip failed: %v", n, err)
+		}
+	}
+
+	// XTS keys have no CBC mode
+	xts, err := aes.NewCipher(make([]byte, 64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := keywrap.Wrap(xts, make([]byte, 16)); err == nil {
+		t.Error("wrapped with an XTS-256 key")
+	}
+}
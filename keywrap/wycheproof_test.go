@@ -0,0 +1,76 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package keywrap_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cipher"
+	"github.com/surendarchandra/crypto/internal/wycheproof"
+	"github.com/surendarchandra/crypto/keywrap"
+)
+
+func runKeywrap(t *testing.T, name string, wrap func(cipher.Block, []byte) ([]byte, error), unwrap func(cipher.Block, []byte) ([]byte, error)) {
+	var file wycheproof.KeywrapFile
+	wycheproof.Load(t, name, &file)
+
+	tally := wycheproof.NewTally(t)
+	defer tally.Report()
+
+	for _, group := range file.TestGroups {
+		for _, tc := range group.Tests {
+			kek, err := aes.NewCipher(tc.Key)
+			if err != nil {
+				tally.Skip(tc.Test)
+				continue
+			}
+
+			match := true
+			if tc.Result == "valid" {
+				ct, err := wrap(kek, tc.Msg)
+				match = err == nil && bytes.Equal(ct, tc.Ct)
+			}
+
+			pt, err := unwrap(kek, tc.Ct)
+			tally.Check(tc.Test, err == nil, match && bytes.Equal(pt, tc.Msg))
+		}
+	}
+}
+
+func TestWycheproofKW(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	runKeywrap(t, "aes_wrap_test.json", keywrap.Wrap, keywrap.Unwrap)
+}
+
+func TestWycheproofKWP(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	runKeywrap(t, "aes_kwp_test.json", keywrap.WrapPad, keywrap.UnwrapPad)
+}
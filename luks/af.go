@@ -0,0 +1,123 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package luks
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"strings"
+)
+
+// hashes are the hash specs of cryptsetup the package supports.
+var hashes = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha224": sha256.New224,
+	"sha256": sha256.New,
+	"sha384": sha512.New384,
+	"sha512": sha512.New,
+}
+
+func newHash(spec string) (func() hash.Hash, error) {
+	h, ok := hashes[strings.ToLower(spec)]
+	if !ok {
+		return nil, errors.New("luks: unsupported hash " + spec)
+	}
+	return h, nil
+}
+
+// pbkdf2 derives a keyLen byte key from password (RFC 8018 section 5.2).
+func pbkdf2(h func() hash.Hash, password, salt []byte, iter, keyLen int) []byte {
+	prf := hmac.New(h, password)
+	hashLen := prf.Size()
+	numBlocks := (keyLen + hashLen - 1) / hashLen
+
+	var buf [4]byte
+	dk := make([]byte, 0, numBlocks*hashLen)
+	u := make([]byte, hashLen)
+	for block := 1; block <= numBlocks; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		binary.BigEndian.PutUint32(buf[:], uint32(block))
+		prf.Write(buf[:])
+		dk = prf.Sum(dk)
+		t := dk[len(dk)-hashLen:]
+		copy(u, t)
+
+		for n := 2; n <= iter; n++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for i := range u {
+				t[i] ^= u[i]
+			}
+		}
+	}
+
+	return dk[:keyLen]
+}
+
+// diffuse replaces b with its hash, a digest size block at a time, each
+// block hashed after its big-endian index and the last cut to size.
+func diffuse(h func() hash.Hash, b []byte) {
+	d := h()
+	var index [4]byte
+	var sum []byte
+	for i := 0; len(b) > 0; i++ {
+		n := d.Size()
+		if n > len(b) {
+			n = len(b)
+		}
+
+		d.Reset()
+		binary.BigEndian.PutUint32(index[:], uint32(i))
+		d.Write(index[:])
+		d.Write(b[:n])
+		sum = d.Sum(sum[:0])
+		copy(b, sum[:n])
+
+		b = b[n:]
+	}
+}
+
+// afMerge recovers the key that the anti-forensic splitter of LUKS
+// spread over stripes blocks of the key size in src.
+func afMerge(h func() hash.Hash, src []byte, keySize, stripes int) []byte {
+	key := make([]byte, keySize)
+	for i := 0; i < stripes-1; i++ {
+		for j := range key {
+			key[j] ^= src[i*keySize+j]
+		}
+		diffuse(h, key)
+	}
+	last := src[(stripes-1)*keySize:]
+	for j := range key {
+		key[j] ^= last[j]
+	}
+
+	return key
+}
@@ -0,0 +1,274 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package luks reads LUKS1 and LUKS2 encrypted volumes, as cryptsetup
+// formats them on Linux: it parses the header (LUKS2's binary header and
+// JSON metadata), unlocks a keyslot with a passphrase, which recovers and
+// verifies the volume key, and decrypts the data segment as an
+// io.ReaderAt.
+//
+// Keyslots derive their key with PBKDF2; Argon2 keyslots are skipped.
+// The data segment and keyslots may be encrypted with any cipher spec of
+// package dmcrypt, such as aes-xts-plain64 and aes-cbc-essiv:sha256. The
+// package does not write volumes.
+package luks
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"errors"
+	"io"
+	"sort"
+
+	"github.com/surendarchandra/crypto/dmcrypt"
+)
+
+var (
+	// ErrPassphrase is returned when no keyslot opens with the
+	// passphrase.
+	ErrPassphrase = errors.New("luks: no keyslot matches the passphrase")
+
+	errFormat  = errors.New("luks: not a LUKS volume")
+	errKeyslot = errors.New("luks: no such keyslot")
+	errKey     = errors.New("luks: volume key does not match the digest")
+
+	magic  = []byte{'L', 'U', 'K', 'S', 0xba, 0xbe}
+	magic2 = []byte{'S', 'K', 'U', 'L', 0xba, 0xbe}
+)
+
+// A Device is an opened LUKS volume.
+type Device struct {
+	// Version is the LUKS version, 1 or 2.
+	Version int
+
+	// UUID identifies the volume; Label names a LUKS2 volume.
+	UUID, Label string
+
+	// Cipher is the encryption of the data segment as a dm-crypt cipher
+	// spec, such as "aes-xts-plain64", and KeySize the size of the
+	// volume key in bytes.
+	Cipher  string
+	KeySize int
+
+	// SectorSize is the size of the sectors of the data segment.
+	SectorSize int
+
+	// Offset is where the data segment starts on the device; Size is
+	// its size, or -1 if it runs to the end of the device.
+	Offset, Size int64
+
+	// Keyslots are the active keyslots, ordered by ID.
+	Keyslots []Keyslot
+
+	r       io.ReaderAt
+	ivTweak uint64
+	digests []digest
+}
+
+// A Keyslot holds the volume key encrypted under a passphrase.
+type Keyslot struct {
+	ID int
+
+	// Priority is the LUKS2 priority: 0 keyslots are skipped by Unlock,
+	// which tries 2 before 1. LUKS1 keyslots have priority 1.
+	Priority int
+
+	// KDF is the key derivation: "pbkdf2", "argon2i" or "argon2id";
+	// Hash and Iterations are those of PBKDF2.
+	KDF        string
+	Hash       string
+	Iterations int
+
+	// Cipher is the encryption of the keyslot area and Stripes the
+	// number of anti-forensic stripes of the key.
+	Cipher  string
+	Stripes int
+
+	salt        []byte
+	keySize     int // of the volume key it holds
+	areaKeySize int // of the keyslot area encryption
+	afHash      string
+	offset      int64 // of the keyslot area
+}
+
+// maxIterations bounds the PBKDF2 iterations a header may ask for, and
+// with them the work an unlock attempt spends on untrusted metadata. It is
+// well above what cryptsetup benchmarks to on current machines.
+const maxIterations = 1 << 26
+
+// A digest verifies the volume keys of keyslots and, if segment is set,
+// of the data segment.
+type digest struct {
+	keyslots   []int
+	segment    bool
+	hash       string
+	iterations int
+	salt       []byte
+	value      []byte
+}
+
+// Open reads the header of the LUKS volume on r, which the Device keeps
+// reading keyslots and data from.
+func Open(r io.ReaderAt) (*Device, error) {
+	var hdr [8]byte
+	if err := readAt(r, hdr[:], 0); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return nil, errFormat
+		}
+		return nil, err
+	}
+
+	var d *Device
+	var err error
+	if bytes.Equal(hdr[:6], magic) && hdr[6] == 0 && hdr[7] == 1 {
+		d, err = openLUKS1(r)
+	} else {
+		// A damaged primary LUKS2 header may leave a secondary one
+		d, err = openLUKS2(r)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(d.Keyslots, func(i, j int) bool { return d.Keyslots[i].ID < d.Keyslots[j].ID })
+	return d, nil
+}
+
+// readAt reads all of p from r at off.
+func readAt(r io.ReaderAt, p []byte, off int64) error {
+	n, err := r.ReadAt(p, off)
+	if n == len(p) {
+		return nil
+	}
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// Unlock returns the volume key, trying the passphrase on each PBKDF2
+// keyslot in order of priority.
+func (d *Device) Unlock(passphrase []byte) ([]byte, error) {
+	for _, priority := range []int{2, 1} {
+		for _, ks := range d.Keyslots {
+			if ks.Priority != priority || ks.KDF != "pbkdf2" {
+				continue
+			}
+
+			key, err := d.UnlockKeyslot(ks.ID, passphrase)
+			if err == nil {
+				return key, nil
+			}
+			if err != ErrPassphrase {
+				return nil, err
+			}
+		}
+	}
+
+	return nil, ErrPassphrase
+}
+
+// UnlockKeyslot returns the volume key held by keyslot id, or
+// ErrPassphrase if the passphrase is not the keyslot's.
+func (d *Device) UnlockKeyslot(id int, passphrase []byte) ([]byte, error) {
+	var ks *Keyslot
+	for i := range d.Keyslots {
+		if d.Keyslots[i].ID == id {
+			ks = &d.Keyslots[i]
+		}
+	}
+	if ks == nil {
+		return nil, errKeyslot
+	}
+	if ks.KDF != "pbkdf2" {
+		return nil, errors.New("luks: unsupported key derivation " + ks.KDF)
+	}
+
+	h, err := newHash(ks.Hash)
+	if err != nil {
+		return nil, err
+	}
+	afHash, err := newHash(ks.afHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// The key material fills whole 512 byte sectors numbered from the
+	// start of the area
+	size := ks.keySize * ks.Stripes
+	area := make([]byte, (size+511)/512*512)
+	if err := readAt(d.r, area, ks.offset); err != nil {
+		return nil, err
+	}
+
+	sc, err := dmcrypt.NewSectorCipher(ks.Cipher, pbkdf2(h, passphrase, ks.salt, ks.Iterations, ks.areaKeySize), 512)
+	if err != nil {
+		return nil, err
+	}
+	material := make([]byte, len(area))
+	if err := sc.Decrypt(material, area, 0); err != nil {
+		return nil, err
+	}
+	key := afMerge(afHash, material[:size], ks.keySize, ks.Stripes)
+
+	for _, dg := range d.digests {
+		for _, k := range dg.keyslots {
+			if k != id {
+				continue
+			}
+			ok, err := dg.verify(key)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return nil, ErrPassphrase
+			}
+			return key, nil
+		}
+	}
+
+	return nil, errors.New("luks: keyslot has no digest")
+}
+
+// VerifyKey reports whether key is the volume key of the data segment.
+func (d *Device) VerifyKey(key []byte) (bool, error) {
+	if len(key) != d.KeySize {
+		return false, nil
+	}
+	for _, dg := range d.digests {
+		if dg.segment {
+			return dg.verify(key)
+		}
+	}
+
+	return false, errors.New("luks: data segment has no digest")
+}
+
+func (dg *digest) verify(key []byte) (bool, error) {
+	h, err := newHash(dg.hash)
+	if err != nil {
+		return false, err
+	}
+	sum := pbkdf2(h, key, dg.salt, dg.iterations, len(dg.value))
+
+	return subtle.ConstantTimeCompare(sum, dg.value) == 1, nil
+}
@@ -0,0 +1,133 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package luks
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// The LUKS1 header (LUKS On-Disk Format Specification 1.2.3) is 592
+// bytes, with big-endian integers and NUL padded strings:
+//
+//	magic (6) | version (2) | cipher name (32) | cipher mode (32) |
+//	hash spec (32) | payload offset (4) | key bytes (4) |
+//	master key digest (20) | digest salt (32) | digest iterations (4) |
+//	UUID (40) | 8 keyslots
+//
+// and each keyslot
+//
+//	active (4) | iterations (4) | salt (32) | key material offset (4) |
+//	stripes (4)
+//
+// Offsets are in 512 byte sectors.
+const (
+	luks1HeaderSize  = 592
+	luks1KeyslotSize = 48
+	luks1Keyslots    = 8
+	luks1Active      = 0x00ac71f3
+	luks1Inactive    = 0x0000dead
+	luks1DigestSize  = 20
+)
+
+func cString(b []byte) string {
+	if i := bytes.IndexByte(b, 0); i >= 0 {
+		b = b[:i]
+	}
+	return string(b)
+}
+
+func openLUKS1(r io.ReaderAt) (*Device, error) {
+	var hdr [luks1HeaderSize]byte
+	if err := readAt(r, hdr[:], 0); err != nil {
+		return nil, err
+	}
+
+	hash := cString(hdr[72:104])
+	keyBytes := int(binary.BigEndian.Uint32(hdr[108:]))
+	if keyBytes <= 0 || keyBytes > 512 {
+		return nil, errors.New("luks: invalid key size")
+	}
+
+	d := &Device{
+		Version:    1,
+		UUID:       cString(hdr[168:208]),
+		Cipher:     cString(hdr[8:40]) + "-" + cString(hdr[40:72]),
+		KeySize:    keyBytes,
+		SectorSize: 512,
+		Offset:     int64(binary.BigEndian.Uint32(hdr[104:])) * 512,
+		Size:       -1,
+		r:          r,
+	}
+	iterations := int(binary.BigEndian.Uint32(hdr[164:]))
+	if iterations < 1 || iterations > maxIterations {
+		return nil, errors.New("luks: invalid number of iterations")
+	}
+	dg := digest{
+		segment:    true,
+		hash:       hash,
+		iterations: iterations,
+		salt:       append([]byte(nil), hdr[132:164]...),
+		value:      append([]byte(nil), hdr[112:132]...),
+	}
+
+	for i := 0; i < luks1Keyslots; i++ {
+		ks := hdr[208+i*luks1KeyslotSize:][:luks1KeyslotSize]
+		switch binary.BigEndian.Uint32(ks) {
+		case luks1Inactive:
+			continue
+		case luks1Active:
+		default:
+			return nil, errors.New("luks: invalid keyslot state")
+		}
+
+		stripes := int(binary.BigEndian.Uint32(ks[44:]))
+		if stripes < 1 || stripes > 1<<20 {
+			return nil, errors.New("luks: invalid number of stripes")
+		}
+		iterations := int(binary.BigEndian.Uint32(ks[4:]))
+		if iterations < 1 || iterations > maxIterations {
+			return nil, errors.New("luks: invalid number of iterations")
+		}
+		d.Keyslots = append(d.Keyslots, Keyslot{
+			ID:          i,
+			Priority:    1,
+			KDF:         "pbkdf2",
+			Hash:        hash,
+			Iterations:  iterations,
+			Cipher:      d.Cipher,
+			Stripes:     stripes,
+			salt:        append([]byte(nil), ks[8:40]...),
+			keySize:     keyBytes,
+			areaKeySize: keyBytes,
+			afHash:      hash,
+			offset:      int64(binary.BigEndian.Uint32(ks[40:])) * 512,
+		})
+		dg.keyslots = append(dg.keyslots, i)
+	}
+	d.digests = []digest{dg}
+
+	return d, nil
+}
@@ -0,0 +1,318 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package luks
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// A LUKS2 header (LUKS2 On-Disk Format Specification 1.1.x) is a 4096
+// byte binary header, with big-endian integers and NUL padded strings,
+//
+//	magic (6) | version (2) | header size (8) | sequence ID (8) |
+//	label (48) | checksum algorithm (32) | salt (64) | UUID (40) |
+//	subsystem (48) | header offset (8) | padding (184) | checksum (64) |
+//	padding (3584)
+//
+// followed by the JSON metadata, NUL padded to the header size. The
+// checksum covers the whole header with the checksum field zeroed. A
+// secondary copy, with magic "SKUL\xba\xbe", follows the primary; the
+// valid copy with the highest sequence ID is used.
+const luks2BinarySize = 4096
+
+// luks2Offsets are where LUKS2 headers may be: the primary at 0 and the
+// secondary at any of the header sizes.
+var luks2Offsets = []int64{0, 0x4000, 0x8000, 0x10000, 0x20000, 0x40000, 0x80000, 0x100000, 0x200000, 0x400000}
+
+type luks2Metadata struct {
+	Keyslots map[string]luks2Keyslot `json:"keyslots"`
+	Segments map[string]luks2Segment `json:"segments"`
+	Digests  map[string]luks2Digest  `json:"digests"`
+	Config   struct {
+		Requirements struct {
+			Mandatory []string `json:"mandatory"`
+		} `json:"requirements"`
+	} `json:"config"`
+}
+
+type luks2Keyslot struct {
+	Type     string `json:"type"`
+	KeySize  int    `json:"key_size"`
+	Priority *int   `json:"priority"`
+	AF       struct {
+		Type    string `json:"type"`
+		Stripes int    `json:"stripes"`
+		Hash    string `json:"hash"`
+	} `json:"af"`
+	Area struct {
+		Type       string `json:"type"`
+		Offset     int64  `json:"offset,string"`
+		Size       int64  `json:"size,string"`
+		Encryption string `json:"encryption"`
+		KeySize    int    `json:"key_size"`
+	} `json:"area"`
+	KDF struct {
+		Type       string `json:"type"`
+		Hash       string `json:"hash"`
+		Iterations int    `json:"iterations"`
+		Salt       []byte `json:"salt"`
+	} `json:"kdf"`
+}
+
+type luks2Segment struct {
+	Type       string          `json:"type"`
+	Offset     int64           `json:"offset,string"`
+	Size       string          `json:"size"`
+	IVTweak    uint64          `json:"iv_tweak,string"`
+	Encryption string          `json:"encryption"`
+	SectorSize int             `json:"sector_size"`
+	Integrity  json.RawMessage `json:"integrity"`
+}
+
+type luks2Digest struct {
+	Type       string   `json:"type"`
+	Keyslots   []string `json:"keyslots"`
+	Segments   []string `json:"segments"`
+	Hash       string   `json:"hash"`
+	Iterations int      `json:"iterations"`
+	Salt       []byte   `json:"salt"`
+	Digest     []byte   `json:"digest"`
+}
+
+// readLUKS2Header returns the binary header and JSON metadata of the
+// LUKS2 header at off, if it is valid.
+func readLUKS2Header(r io.ReaderAt, off int64) ([]byte, []byte, error) {
+	bin := make([]byte, luks2BinarySize)
+	if err := readAt(r, bin, off); err != nil {
+		return nil, nil, err
+	}
+
+	m := magic
+	if off != 0 {
+		m = magic2
+	}
+	size := binary.BigEndian.Uint64(bin[8:])
+	if !bytes.Equal(bin[:6], m) || binary.BigEndian.Uint16(bin[6:]) != 2 ||
+		binary.BigEndian.Uint64(bin[256:]) != uint64(off) ||
+		size < 0x4000 || size > 0x400000 || size&(size-1) != 0 {
+		return nil, nil, errFormat
+	}
+
+	h, err := newHash(cString(bin[72:104]))
+	if err != nil {
+		return nil, nil, err
+	}
+	hdr := make([]byte, size)
+	copy(hdr, bin)
+	if err := readAt(r, hdr[luks2BinarySize:], off+luks2BinarySize); err != nil {
+		return nil, nil, err
+	}
+
+	d := h()
+	if d.Size() > 64 {
+		return nil, nil, errFormat
+	}
+	want := append([]byte(nil), hdr[448:448+d.Size()]...)
+	for i := 448; i < 512; i++ {
+		hdr[i] = 0
+	}
+	d.Write(hdr)
+	if subtle.ConstantTimeCompare(d.Sum(nil), want) != 1 {
+		return nil, nil, errors.New("luks: header checksum mismatch")
+	}
+	copy(hdr[448:], want)
+
+	js := hdr[luks2BinarySize:]
+	if i := bytes.IndexByte(js, 0); i >= 0 {
+		js = js[:i]
+	}
+
+	return hdr[:luks2BinarySize], js, nil
+}
+
+func openLUKS2(r io.ReaderAt) (*Device, error) {
+	var bin, js []byte
+	var seqid uint64
+	for _, off := range luks2Offsets {
+		b, j, err := readLUKS2Header(r, off)
+		if err != nil {
+			continue
+		}
+		if s := binary.BigEndian.Uint64(b[16:]); bin == nil || s > seqid {
+			bin, js, seqid = b, j, s
+		}
+	}
+	if bin == nil {
+		return nil, errFormat
+	}
+
+	var md luks2Metadata
+	if err := json.Unmarshal(js, &md); err != nil {
+		return nil, errors.New("luks: invalid metadata: " + err.Error())
+	}
+	var segments, digests, keyslots []string
+	for id := range md.Segments {
+		segments = append(segments, id)
+	}
+	for id := range md.Digests {
+		digests = append(digests, id)
+	}
+	for id := range md.Keyslots {
+		keyslots = append(keyslots, id)
+	}
+	if len(md.Config.Requirements.Mandatory) > 0 {
+		return nil, errors.New("luks: unsupported requirement " + md.Config.Requirements.Mandatory[0])
+	}
+
+	d := &Device{
+		Version: 2,
+		UUID:    cString(bin[168:208]),
+		Label:   cString(bin[24:72]),
+		r:       r,
+	}
+
+	// The data segment is the first crypt segment
+	segment := ""
+	for _, id := range sortIDs(segments) {
+		if md.Segments[id].Type == "crypt" {
+			segment = id
+			break
+		}
+	}
+	if segment == "" {
+		return nil, errors.New("luks: no crypt segment")
+	}
+	seg := md.Segments[segment]
+	if seg.Integrity != nil {
+		return nil, errors.New("luks: integrity protected segments not supported")
+	}
+	if seg.SectorSize < 512 || seg.SectorSize > 4096 || seg.SectorSize&(seg.SectorSize-1) != 0 {
+		return nil, errors.New("luks: invalid sector size")
+	}
+	d.Cipher = seg.Encryption
+	d.SectorSize = seg.SectorSize
+	d.Offset = seg.Offset
+	d.ivTweak = seg.IVTweak
+	d.Size = -1
+	if seg.Size != "dynamic" {
+		size, err := strconv.ParseInt(seg.Size, 10, 64)
+		if err != nil || size < 0 || size%int64(seg.SectorSize) != 0 {
+			return nil, errors.New("luks: invalid segment size")
+		}
+		d.Size = size
+	}
+
+	for _, id := range sortIDs(digests) {
+		dg := md.Digests[id]
+		if dg.Type != "pbkdf2" {
+			continue
+		}
+
+		if dg.Iterations < 1 || dg.Iterations > maxIterations || len(dg.Digest) < 1 || len(dg.Digest) > 64 {
+			return nil, errors.New("luks: invalid digest " + id)
+		}
+
+		v := digest{hash: dg.Hash, iterations: dg.Iterations, salt: dg.Salt, value: dg.Digest}
+		for _, s := range dg.Segments {
+			v.segment = v.segment || s == segment
+		}
+		for _, k := range dg.Keyslots {
+			n, err := strconv.Atoi(k)
+			if err != nil {
+				return nil, errors.New("luks: invalid keyslot ID")
+			}
+			v.keyslots = append(v.keyslots, n)
+		}
+		d.digests = append(d.digests, v)
+	}
+
+	for _, id := range sortIDs(keyslots) {
+		ks := md.Keyslots[id]
+		if ks.Type != "luks2" {
+			continue
+		}
+		n, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, errors.New("luks: invalid keyslot ID")
+		}
+		if ks.AF.Type != "luks1" || ks.Area.Type != "raw" {
+			return nil, errors.New("luks: unsupported keyslot " + id)
+		}
+		if ks.KeySize <= 0 || ks.KeySize > 512 || ks.AF.Stripes < 1 || ks.AF.Stripes > 1<<20 ||
+			ks.Area.KeySize <= 0 || ks.Area.KeySize > 512 ||
+			ks.Area.Offset < 0 || ks.Area.Size < int64(ks.KeySize*ks.AF.Stripes) {
+			return nil, errors.New("luks: invalid keyslot " + id)
+		}
+		if ks.KDF.Type == "pbkdf2" && (ks.KDF.Iterations < 1 || ks.KDF.Iterations > maxIterations) {
+			return nil, errors.New("luks: invalid keyslot " + id)
+		}
+
+		priority := 1
+		if ks.Priority != nil {
+			priority = *ks.Priority
+		}
+		d.Keyslots = append(d.Keyslots, Keyslot{
+			ID:          n,
+			Priority:    priority,
+			KDF:         ks.KDF.Type,
+			Hash:        ks.KDF.Hash,
+			Iterations:  ks.KDF.Iterations,
+			Cipher:      ks.Area.Encryption,
+			Stripes:     ks.AF.Stripes,
+			salt:        ks.KDF.Salt,
+			keySize:     ks.KeySize,
+			areaKeySize: ks.Area.KeySize,
+			afHash:      ks.AF.Hash,
+			offset:      ks.Area.Offset,
+		})
+
+		for _, dg := range d.digests {
+			for _, k := range dg.keyslots {
+				if k == n && dg.segment && d.KeySize == 0 {
+					d.KeySize = ks.KeySize
+				}
+			}
+		}
+	}
+
+	return d, nil
+}
+
+// sortIDs sorts the keys of a JSON object of LUKS2 metadata in numeric
+// order.
+func sortIDs(ids []string) []string {
+	sort.Slice(ids, func(i, j int) bool {
+		a, _ := strconv.Atoi(ids[i])
+		b, _ := strconv.Atoi(ids[j])
+		return a < b
+	})
+
+	return ids
+}
@@ -0,0 +1,375 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package luks_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/luks"
+)
+
+type volume struct {
+	file        string
+	version     int
+	uuid, label string
+	cipher      string
+	keySize     int
+	sectorSize  int
+	offset      int64
+	keyslots    []int
+	passphrases map[int]string
+}
+
+// The volumes under testdata are written by testdata/gen.go.
+var volumes = []volume{
+	{
+		file:        "luks1-aes-xts-plain64.img.gz",
+		version:     1,
+		uuid:        "8c6e9b7a-4e1f-4a0d-9c51-3b7f2a6d1e08",
+		cipher:      "aes-xts-plain64",
+		keySize:     32,
+		sectorSize:  512,
+		offset:      2 << 20,
+		keyslots:    []int{0},
+		passphrases: map[int]string{0: "luks1 passphrase"},
+	},
+	{
+		file:        "luks1-aes-cbc-essiv.img.gz",
+		version:     1,
+		uuid:        "d41f7c2b-93a5-4b6e-8f10-6a2e5c9d7b31",
+		cipher:      "aes-cbc-essiv:sha256",
+		keySize:     16,
+		sectorSize:  512,
+		offset:      2 << 20,
+		keyslots:    []int{0, 2},
+		passphrases: map[int]string{0: "first passphrase", 2: "second passphrase"},
+	},
+	{
+		file:        "luks2-aes-xts-plain64.img.gz",
+		version:     2,
+		uuid:        "2f4b1c9e-7d3a-4e8b-a6f0-5c2d8e1b9a74",
+		label:       "fixture",
+		cipher:      "aes-xts-plain64",
+		keySize:     32,
+		sectorSize:  4096,
+		offset:      294912,
+		keyslots:    []int{0, 1},
+		passphrases: map[int]string{1: "luks2 passphrase"},
+	},
+}
+
+// The cryptsetup volumes are written by libcryptsetup itself, through
+// testdata/cryptsetup.sh.
+var cryptsetupVolumes = []volume{
+	{
+		file:        "cryptsetup-luks1-aes-cbc-essiv.img.gz",
+		version:     1,
+		uuid:        "6a0c5e3f-1b2d-4c7e-9f80-2d4b6e8a1c35",
+		cipher:      "aes-cbc-essiv:sha256",
+		keySize:     32,
+		sectorSize:  512,
+		offset:      2 << 20,
+		keyslots:    []int{0},
+		passphrases: map[int]string{0: "cryptsetup luks1"},
+	},
+	{
+		file:        "cryptsetup-luks2-aes-xts-plain64.img.gz",
+		version:     2,
+		uuid:        "9e3d7a1b-5c2f-4e80-b6a4-1f8c3d5e7b92",
+		label:       "cryptsetup",
+		cipher:      "aes-xts-plain64",
+		keySize:     64,
+		sectorSize:  4096,
+		offset:      294912,
+		keyslots:    []int{0},
+		passphrases: map[int]string{0: "cryptsetup luks2"},
+	},
+}
+
+func readVolume(t *testing.T, file string) []byte {
+	t.Helper()
+
+	f, err := os.Open(filepath.Join("testdata", file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return img
+}
+
+// plaintext is the data gen.go and cryptsetup.c store, numbered 16 byte
+// lines.
+func plaintext(n int) []byte {
+	var b []byte
+	for i := 0; len(b) < n; i++ {
+		b = append(b, fmt.Sprintf("%015d\n", i)...)
+	}
+	return b[:n]
+}
+
+func TestOpen(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	for _, v := range volumes {
+		checkVolume(t, v, readVolume(t, v.file))
+	}
+}
+
+func TestOpenCryptsetup(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	for _, v := range cryptsetupVolumes {
+		checkVolume(t, v, readVolume(t, v.file))
+	}
+}
+
+// checkVolume opens img, unlocks every keyslot of v and reads back the
+// data.
+func checkVolume(t *testing.T, v volume, img []byte) {
+	t.Helper()
+
+	d, err := luks.Open(bytes.NewReader(img))
+	if err != nil {
+		t.Fatalf("%s: %v", v.file, err)
+	}
+
+	if d.Version != v.version || d.UUID != v.uuid || d.Label != v.label || d.Cipher != v.cipher ||
+		d.KeySize != v.keySize || d.SectorSize != v.sectorSize || d.Offset != v.offset || d.Size != -1 {
+		t.Errorf("%s: got %+v", v.file, d)
+	}
+	var ids []int
+	for _, ks := range d.Keyslots {
+		ids = append(ids, ks.ID)
+	}
+	if fmt.Sprint(ids) != fmt.Sprint(v.keyslots) {
+		t.Errorf("%s: keyslots %v, want %v", v.file, ids, v.keyslots)
+	}
+
+	var key []byte
+	for id, passphrase := range v.passphrases {
+		k, err := d.UnlockKeyslot(id, []byte(passphrase))
+		if err != nil {
+			t.Fatalf("%s: keyslot %d: %v", v.file, id, err)
+		}
+		if key != nil && !bytes.Equal(k, key) {
+			t.Errorf("%s: keyslot %d holds another key", v.file, id)
+		}
+		key = k
+
+		if k, err := d.Unlock([]byte(passphrase)); err != nil || !bytes.Equal(k, key) {
+			t.Errorf("%s: Unlock with the passphrase of keyslot %d: %v", v.file, id, err)
+		}
+	}
+	if len(key) != v.keySize {
+		t.Fatalf("%s: %d byte key", v.file, len(key))
+	}
+	if ok, err := d.VerifyKey(key); !ok || err != nil {
+		t.Errorf("%s: VerifyKey returned %t, %v", v.file, ok, err)
+	}
+
+	if _, err := d.Unlock([]byte("wrong passphrase")); err != luks.ErrPassphrase {
+		t.Errorf("%s: Unlock with a wrong passphrase returned %v", v.file, err)
+	}
+	if _, err := d.UnlockKeyslot(7, []byte("wrong passphrase")); err == nil {
+		t.Errorf("%s: UnlockKeyslot accepted an inactive keyslot", v.file)
+	}
+
+	r, err := d.NewReader(key, int64(len(img)))
+	if err != nil {
+		t.Fatalf("%s: %v", v.file, err)
+	}
+	want := plaintext(16 * 1024)
+	if r.Size() != int64(len(want)) {
+		t.Fatalf("%s: data segment of %d bytes", v.file, r.Size())
+	}
+	got := make([]byte, len(want))
+	if n, err := r.ReadAt(got, 0); n != len(want) || err != nil || !bytes.Equal(got, want) {
+		t.Fatalf("%s: ReadAt returned %d, %v; data differs: %t", v.file, n, err, !bytes.Equal(got, want))
+	}
+
+	// Unaligned reads and reads across the end
+	for _, c := range []struct{ off, n int }{{1, 15}, {500, 30}, {4090, 5000}, {len(want) - 10, 20}} {
+		got := make([]byte, c.n)
+		n, err := r.ReadAt(got, int64(c.off))
+		end := c.off + c.n
+		if end > len(want) {
+			end = len(want)
+		}
+		if n != end-c.off || (err == io.EOF) != (end < c.off+c.n) || !bytes.Equal(got[:n], want[c.off:end]) {
+			t.Errorf("%s: ReadAt(%d bytes at %d) returned %d, %v", v.file, c.n, c.off, n, err)
+		}
+	}
+
+	bad := append([]byte(nil), key...)
+	bad[0] ^= 1
+	if _, err := d.NewReader(bad, int64(len(img))); err == nil {
+		t.Errorf("%s: NewReader accepted a wrong key", v.file)
+	}
+}
+
+func TestOpenLUKS2Keyslots(t *testing.T) {
+	img := readVolume(t, "luks2-aes-xts-plain64.img.gz")
+	d, err := luks.Open(bytes.NewReader(img))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ks := d.Keyslots
+	if ks[0].KDF != "argon2id" || ks[1].KDF != "pbkdf2" || ks[1].Hash != "sha256" || ks[1].Iterations != 1000 ||
+		ks[1].Cipher != "aes-xts-plain64" || ks[1].Stripes != 4000 || ks[1].Priority != 1 {
+		t.Errorf("keyslots %+v", ks)
+	}
+	if _, err := d.UnlockKeyslot(0, []byte("luks2 passphrase")); err == nil || err == luks.ErrPassphrase {
+		t.Errorf("UnlockKeyslot of an Argon2 keyslot returned %v", err)
+	}
+}
+
+// TestOpenLUKS2Damaged checks a damaged primary header falls back on the
+// secondary one.
+func TestOpenLUKS2Damaged(t *testing.T) {
+	img := readVolume(t, "luks2-aes-xts-plain64.img.gz")
+
+	primary := append([]byte(nil), img...)
+	primary[4096+10] ^= 1
+	if d, err := luks.Open(bytes.NewReader(primary)); err != nil || d.UUID != volumes[2].uuid {
+		t.Errorf("Open with a damaged primary header returned %v", err)
+	}
+
+	primary[0] = 0
+	if _, err := luks.Open(bytes.NewReader(primary)); err != nil {
+		t.Errorf("Open without a primary header returned %v", err)
+	}
+
+	primary[16384+4096+10] ^= 1
+	if _, err := luks.Open(bytes.NewReader(primary)); err == nil {
+		t.Error("Open accepted damaged headers")
+	}
+}
+
+// rewriteLUKS2 returns img with the JSON metadata of its primary header
+// passed through edit, resealed with a higher sequence ID so that it is
+// the header Open picks.
+func rewriteLUKS2(t *testing.T, img []byte, edit func(md map[string]interface{})) []byte {
+	t.Helper()
+
+	img = append([]byte(nil), img...)
+	size := int(binary.BigEndian.Uint64(img[8:]))
+	hdr := img[:size]
+	js := hdr[4096:]
+	if i := bytes.IndexByte(js, 0); i >= 0 {
+		js = js[:i]
+	}
+
+	var md map[string]interface{}
+	if err := json.Unmarshal(js, &md); err != nil {
+		t.Fatal(err)
+	}
+	edit(md)
+	js, err := json.Marshal(md)
+	if err != nil {
+		t.Fatal(err)
+	}
+	area := hdr[4096:]
+	for i := range area {
+		area[i] = 0
+	}
+	copy(area, js)
+
+	binary.BigEndian.PutUint64(hdr[16:], binary.BigEndian.Uint64(hdr[16:])+1)
+	for i := 448; i < 512; i++ {
+		hdr[i] = 0
+	}
+	sum := sha256.Sum256(hdr)
+	copy(hdr[448:], sum[:])
+
+	return img
+}
+
+// TestOpenLUKS2Limits checks sizes and iteration counts from the metadata
+// are bounded before anything is allocated or derived from them.
+func TestOpenLUKS2Limits(t *testing.T) {
+	img := readVolume(t, "luks2-aes-xts-plain64.img.gz")
+
+	set := func(section, id, field, key string, v interface{}) func(map[string]interface{}) {
+		return func(md map[string]interface{}) {
+			obj := md[section].(map[string]interface{})[id].(map[string]interface{})
+			if field != "" {
+				obj = obj[field].(map[string]interface{})
+			}
+			obj[key] = v
+		}
+	}
+
+	if _, err := luks.Open(bytes.NewReader(rewriteLUKS2(t, img, func(map[string]interface{}) {}))); err != nil {
+		t.Fatalf("Open of resealed metadata returned %v", err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		edit func(map[string]interface{})
+	}{
+		{"a negative area key size", set("keyslots", "1", "area", "key_size", -1)},
+		{"a zero area key size", set("keyslots", "1", "area", "key_size", 0)},
+		{"a large area key size", set("keyslots", "1", "area", "key_size", 4096)},
+		{"a zero KDF iterations", set("keyslots", "1", "kdf", "iterations", 0)},
+		{"a large KDF iterations", set("keyslots", "1", "kdf", "iterations", 1<<40)},
+		{"a zero digest iterations", set("digests", "0", "", "iterations", 0)},
+		{"a large digest iterations", set("digests", "0", "", "iterations", 1<<40)},
+		{"an empty digest", set("digests", "0", "", "digest", "")},
+	} {
+		if _, err := luks.Open(bytes.NewReader(rewriteLUKS2(t, img, tc.edit))); err == nil {
+			t.Errorf("Open accepted metadata with %s", tc.name)
+		}
+	}
+}
+
+func TestOpenInvalid(t *testing.T) {
+	for i, img := range [][]byte{nil, make([]byte, 1<<20), []byte("LUKS\xba\xbe\x00\x03")} {
+		if _, err := luks.Open(bytes.NewReader(img)); err == nil {
+			t.Errorf("Open accepted image %d", i)
+		}
+	}
+}
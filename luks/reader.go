@@ -0,0 +1,127 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package luks
+
+import (
+	"errors"
+	"io"
+
+	"github.com/surendarchandra/crypto/dmcrypt"
+)
+
+// readerChunkSize is how much a Reader reads and decrypts at a time.
+const readerChunkSize = 64 * 1024
+
+// A Reader reads the decrypted data segment of a LUKS volume. It is safe
+// for concurrent use.
+type Reader struct {
+	r          io.ReaderAt
+	sc         *dmcrypt.SectorCipher
+	sectorSize int
+	offset     int64
+	size       int64
+	ivTweak    uint64
+}
+
+// NewReader returns a Reader of the data segment, decrypted with the
+// volume key. deviceSize is the size of the device, which sets the end
+// of a data segment that runs to the end of the device.
+func (d *Device) NewReader(key []byte, deviceSize int64) (*Reader, error) {
+	ok, err := d.VerifyKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errKey
+	}
+
+	sc, err := dmcrypt.NewSectorCipher(d.Cipher, key, d.SectorSize)
+	if err != nil {
+		return nil, err
+	}
+
+	size := d.Size
+	if size < 0 {
+		if deviceSize < d.Offset {
+			return nil, errors.New("luks: device smaller than the data offset")
+		}
+		size = (deviceSize - d.Offset) / int64(d.SectorSize) * int64(d.SectorSize)
+	}
+
+	return &Reader{
+		r:          d.r,
+		sc:         sc,
+		sectorSize: d.SectorSize,
+		offset:     d.Offset,
+		size:       size,
+		ivTweak:    d.ivTweak,
+	}, nil
+}
+
+// Size returns the size of the data segment.
+func (r *Reader) Size() int64 {
+	return r.size
+}
+
+// ReadAt implements io.ReaderAt. Reads of partial sectors decrypt the
+// whole sectors.
+func (r *Reader) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, errors.New("luks: negative offset")
+	}
+	if off >= r.size {
+		return 0, io.EOF
+	}
+	if int64(len(p)) > r.size-off {
+		p = p[:r.size-off]
+		err = io.EOF
+	}
+
+	ss := int64(r.sectorSize)
+	var ct, pt []byte
+	for n < len(p) {
+		start := off / ss * ss
+		skip := int(off - start)
+		m := (skip + len(p) - n + r.sectorSize - 1) / r.sectorSize * r.sectorSize
+		if m > readerChunkSize {
+			m = readerChunkSize
+		}
+		if ct == nil {
+			ct, pt = make([]byte, m), make([]byte, m)
+		}
+
+		if err := readAt(r.r, ct[:m], r.offset+start); err != nil {
+			return n, err
+		}
+		// IVs count 512 byte sectors, whatever the sector size
+		if err := r.sc.Decrypt(pt[:m], ct[:m], r.ivTweak+uint64(start/512)); err != nil {
+			return n, err
+		}
+
+		c := copy(p[n:], pt[skip:m])
+		n += c
+		off += int64(c)
+	}
+
+	return n, err
+}
@@ -0,0 +1,154 @@
+/*
+ * Writes the cryptsetup-*.img volumes that TestOpenCryptsetup checks:
+ * headers, keyslots and data all written by libcryptsetup, to cross-check
+ * gen.go's volumes. cryptsetup.sh builds and runs it.
+ *
+ * The device mapper is not needed. The data is encrypted by an offline
+ * LUKS2 encryption against a detached header, which runs the cipher in
+ * userspace, and the volume is then formatted with the same volume key in
+ * front of a copy of that ciphertext. The IVs of both the ESSIV and plain64
+ * specs depend only on the key and the sector number within the segment,
+ * so the copy decrypts the same.
+ */
+#include <errno.h>
+#include <fcntl.h>
+#include <stdio.h>
+#include <stdlib.h>
+#include <string.h>
+#include <unistd.h>
+
+#include <libcryptsetup.h>
+
+#define DATA_SIZE 16384
+
+static void check(int r, const char *what)
+{
+	if (r < 0) {
+		fprintf(stderr, "%s: %s\n", what, strerror(-r));
+		exit(1);
+	}
+}
+
+/* writedata writes the numbered 16 byte lines gen.go stores into fd at off */
+static void writedata(int fd, off_t off)
+{
+	char line[17];
+
+	for (int i = 0; i < DATA_SIZE / 16; i++) {
+		snprintf(line, sizeof(line), "%015d\n", i);
+		if (pwrite(fd, line, 16, off + 16 * i) != 16)
+			check(-errno, "pwrite");
+	}
+}
+
+/*
+ * encrypt encrypts the data with a fresh volume key of key_size bytes
+ * under cipher and mode and returns the ciphertext and key.
+ */
+static void encrypt(const char *cipher, const char *mode, size_t key_size,
+		    uint32_t sector_size, char *ciphertext, char *key)
+{
+	char hdr[] = "/tmp/cryptsetup-hdr-XXXXXX", data[] = "/tmp/cryptsetup-data-XXXXXX";
+	const char *pass = "encrypt";
+	struct crypt_pbkdf_type pbkdf = {
+		.type = CRYPT_KDF_PBKDF2, .hash = "sha256", .iterations = 1000,
+		.flags = CRYPT_PBKDF_NO_BENCHMARK,
+	};
+	struct crypt_params_luks2 params = {
+		.pbkdf = &pbkdf, .sector_size = sector_size, .data_device = data,
+	};
+	struct crypt_params_reencrypt reenc = {
+		.mode = CRYPT_REENCRYPT_ENCRYPT, .direction = CRYPT_REENCRYPT_FORWARD,
+		.resilience = "none", .luks2 = &params,
+		.flags = CRYPT_REENCRYPT_INITIALIZE_ONLY,
+	};
+	struct crypt_device *cd;
+	size_t n = key_size;
+	int hfd, dfd, slot;
+
+	hfd = mkstemp(hdr);
+	dfd = mkstemp(data);
+	if (hfd < 0 || dfd < 0)
+		check(-errno, "mkstemp");
+	check(ftruncate(hfd, 16 << 20) ? -errno : 0, "ftruncate");
+	writedata(dfd, 0);
+
+	check(crypt_init(&cd, hdr), "crypt_init");
+	check(crypt_set_pbkdf_type(cd, &pbkdf), "crypt_set_pbkdf_type");
+	check(crypt_format(cd, CRYPT_LUKS2, cipher, mode, NULL, NULL, key_size, &params), "crypt_format");
+	slot = crypt_keyslot_add_by_volume_key(cd, CRYPT_ANY_SLOT, NULL, 0, pass, strlen(pass));
+	check(slot, "crypt_keyslot_add_by_volume_key");
+	check(crypt_reencrypt_init_by_passphrase(cd, NULL, pass, strlen(pass), CRYPT_ANY_SLOT,
+						 slot, cipher, mode, &reenc), "crypt_reencrypt_init");
+	crypt_free(cd);
+
+	check(crypt_init_data_device(&cd, hdr, data), "crypt_init_data_device");
+	check(crypt_load(cd, CRYPT_LUKS2, NULL), "crypt_load");
+	reenc.flags = 0;
+	check(crypt_reencrypt_init_by_passphrase(cd, NULL, pass, strlen(pass), CRYPT_ANY_SLOT,
+						 slot, NULL, NULL, &reenc), "crypt_reencrypt_init");
+	check(crypt_reencrypt_run(cd, NULL, NULL), "crypt_reencrypt_run");
+	check(crypt_volume_key_get(cd, CRYPT_ANY_SLOT, key, &n, pass, strlen(pass)), "crypt_volume_key_get");
+	crypt_free(cd);
+
+	if (pread(dfd, ciphertext, DATA_SIZE, 0) != DATA_SIZE)
+		check(-EIO, "pread");
+	close(hfd);
+	close(dfd);
+	unlink(hdr);
+	unlink(data);
+}
+
+/*
+ * format writes a volume to image: a header of type holding key in
+ * keyslot 0 under passphrase, and the data at offset encrypted with key.
+ */
+static void format(const char *image, const char *type, const char *cipher, const char *mode,
+		   size_t key_size, uint32_t sector_size, uint64_t offset,
+		   const char *uuid, const char *label, const char *passphrase)
+{
+	struct crypt_pbkdf_type pbkdf = {
+		.type = CRYPT_KDF_PBKDF2, .hash = "sha256", .iterations = 1000,
+		.flags = CRYPT_PBKDF_NO_BENCHMARK,
+	};
+	struct crypt_params_luks1 luks1 = { .hash = "sha256", .data_alignment = offset / 512 };
+	struct crypt_params_luks2 luks2 = {
+		.pbkdf = &pbkdf, .sector_size = sector_size, .label = label,
+	};
+	char ciphertext[DATA_SIZE], key[64];
+	struct crypt_device *cd;
+	int fd;
+
+	encrypt(cipher, mode, key_size, sector_size, ciphertext, key);
+
+	fd = open(image, O_RDWR | O_CREAT | O_TRUNC, 0644);
+	if (fd < 0)
+		check(-errno, image);
+	check(ftruncate(fd, offset + DATA_SIZE) ? -errno : 0, "ftruncate");
+
+	check(crypt_init(&cd, image), "crypt_init");
+	check(crypt_set_pbkdf_type(cd, &pbkdf), "crypt_set_pbkdf_type");
+	if (strcmp(type, CRYPT_LUKS2) == 0) {
+		check(crypt_set_data_offset(cd, offset / 512), "crypt_set_data_offset");
+		check(crypt_format(cd, type, cipher, mode, uuid, key, key_size, &luks2), "crypt_format");
+	} else {
+		check(crypt_format(cd, type, cipher, mode, uuid, key, key_size, &luks1), "crypt_format");
+	}
+	check(crypt_keyslot_add_by_volume_key(cd, 0, key, key_size, passphrase, strlen(passphrase)),
+	      "crypt_keyslot_add_by_volume_key");
+	crypt_free(cd);
+
+	if (pwrite(fd, ciphertext, DATA_SIZE, offset) != DATA_SIZE)
+		check(-errno, "pwrite");
+	close(fd);
+}
+
+int main(void)
+{
+	format("cryptsetup-luks1-aes-cbc-essiv.img", CRYPT_LUKS1, "aes", "cbc-essiv:sha256",
+	       32, 512, 2 << 20, "6a0c5e3f-1b2d-4c7e-9f80-2d4b6e8a1c35", NULL, "cryptsetup luks1");
+	format("cryptsetup-luks2-aes-xts-plain64.img", CRYPT_LUKS2, "aes", "xts-plain64",
+	       64, 4096, 288 << 10, "9e3d7a1b-5c2f-4e80-b6a4-1f8c3d5e7b92", "cryptsetup", "cryptsetup luks2");
+
+	return 0;
+}
@@ -0,0 +1,15 @@
+#!/bin/sh
+# Writes the cryptsetup-*.img.gz volumes that TestOpenCryptsetup checks:
+# real libcryptsetup output, to cross-check gen.go's volumes. cryptsetup.c
+# runs the encryption in userspace, so neither root nor the device mapper
+# is needed, only libcryptsetup 2.4 or later and its headers. Run from
+# luks/testdata:
+#
+#	sh cryptsetup.sh
+set -e
+
+gen=$(mktemp)
+trap 'rm -f "$gen"' EXIT
+cc -o "$gen" cryptsetup.c -lcryptsetup
+"$gen"
+gzip -9 -n -f cryptsetup-luks1-aes-cbc-essiv.img cryptsetup-luks2-aes-xts-plain64.img
@@ -0,0 +1,324 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+//go:build ignore
+
+// Gen writes the LUKS test volumes, laid out as cryptsetup formats them,
+// with Go's crypto/aes and crypto/cipher alone so they do not depend on
+// the code under test. The random bytes come from a fixed seed, so the
+// volumes are reproducible:
+//
+//	go run testdata/gen.go
+package main
+
+import (
+	"compress/gzip"
+	"crypto/aes"
+	gocipher "crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"log"
+	"math/rand"
+	"os"
+	"strconv"
+)
+
+var r = rand.New(rand.NewSource(1))
+
+func random(n int) []byte {
+	b := make([]byte, n)
+	r.Read(b)
+	return b
+}
+
+func pbkdf2(h func() hash.Hash, password, salt []byte, iter, keyLen int) []byte {
+	var dk []byte
+	for block := uint32(1); len(dk) < keyLen; block++ {
+		prf := hmac.New(h, password)
+		prf.Write(salt)
+		prf.Write([]byte{byte(block >> 24), byte(block >> 16), byte(block >> 8), byte(block)})
+		u := prf.Sum(nil)
+		t := append([]byte(nil), u...)
+		for n := 1; n < iter; n++ {
+			prf = hmac.New(h, password)
+			prf.Write(u)
+			u = prf.Sum(nil)
+			for i := range t {
+				t[i] ^= u[i]
+			}
+		}
+		dk = append(dk, t...)
+	}
+	return dk[:keyLen]
+}
+
+// afSplit is the anti-forensic splitter of cryptsetup's af.c.
+func afSplit(h func() hash.Hash, key []byte, stripes int) []byte {
+	out := make([]byte, 0, len(key)*stripes)
+	d := make([]byte, len(key))
+	for i := 0; i < stripes-1; i++ {
+		s := random(len(key))
+		out = append(out, s...)
+		for j := range d {
+			d[j] ^= s[j]
+		}
+
+		// Diffuse
+		size := h().Size()
+		for b := 0; b*size < len(d); b++ {
+			end := (b + 1) * size
+			if end > len(d) {
+				end = len(d)
+			}
+			hh := h()
+			hh.Write([]byte{byte(b >> 24), byte(b >> 16), byte(b >> 8), byte(b)})
+			hh.Write(d[b*size : end])
+			copy(d[b*size:end], hh.Sum(nil))
+		}
+	}
+	for j := range d {
+		d[j] ^= key[j]
+	}
+	return append(out, d...)
+}
+
+// encrypt encrypts the sectors of data as dm-crypt's "aes-xts-plain64"
+// or "aes-cbc-essiv:sha256", IVs counting 512 byte sectors from iv.
+func encrypt(spec string, key, data []byte, sectorSize int, iv uint64) []byte {
+	out := make([]byte, len(data))
+	for i := 0; i < len(data); i += sectorSize {
+		var t [16]byte
+		binary.LittleEndian.PutUint64(t[:], iv+uint64(i/512))
+		src, dst := data[i:i+sectorSize], out[i:i+sectorSize]
+
+		switch spec {
+		case "aes-xts-plain64":
+			k1, _ := aes.NewCipher(key[:len(key)/2])
+			k2, _ := aes.NewCipher(key[len(key)/2:])
+			k2.Encrypt(t[:], t[:])
+			for j := 0; j < len(src); j += 16 {
+				var b [16]byte
+				for n := range b {
+					b[n] = src[j+n] ^ t[n]
+				}
+				k1.Encrypt(b[:], b[:])
+				for n := range b {
+					dst[j+n] = b[n] ^ t[n]
+				}
+
+				// Multiply the tweak by x, little-endian
+				carry := t[15] >> 7
+				for n := 15; n > 0; n-- {
+					t[n] = t[n]<<1 | t[n-1]>>7
+				}
+				t[0] = t[0]<<1 ^ 0x87*carry
+			}
+
+		case "aes-cbc-essiv:sha256":
+			salt := sha256.Sum256(key)
+			essiv, _ := aes.NewCipher(salt[:])
+			essiv.Encrypt(t[:], t[:])
+			block, _ := aes.NewCipher(key)
+			gocipher.NewCBCEncrypter(block, t[:]).CryptBlocks(dst, src)
+
+		default:
+			log.Fatal("unknown cipher ", spec)
+		}
+	}
+	return out
+}
+
+// plaintext is the data of each volume, numbered 16 byte lines.
+func plaintext(n int) []byte {
+	var b []byte
+	for i := 0; len(b) < n; i++ {
+		b = append(b, fmt.Sprintf("%015d\n", i)...)
+	}
+	return b[:n]
+}
+
+func write(name string, img []byte) {
+	f, err := os.Create(name)
+	if err != nil {
+		log.Fatal(err)
+	}
+	w, _ := gzip.NewWriterLevel(f, gzip.BestCompression)
+	w.Write(img)
+	if err := w.Close(); err != nil {
+		log.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+type slot1 struct {
+	id         int
+	passphrase string
+}
+
+// luks1 writes a LUKS1 volume with the given keyslots and 16 KiB of
+// data at 2 MiB, as cryptsetup luksFormat --type luks1.
+func luks1(name, uuid, cipherName, cipherMode, hashSpec string, h func() hash.Hash, keyBytes int, slots []slot1) {
+	const stripes, iterations, payload = 4000, 1000, 4096
+	mk := random(keyBytes)
+	spec := cipherName + "-" + cipherMode
+
+	data := plaintext(16 * 1024)
+	img := make([]byte, payload*512+len(data))
+	hdr := img[:592]
+	copy(hdr, "LUKS\xba\xbe\x00\x01")
+	copy(hdr[8:], cipherName)
+	copy(hdr[40:], cipherMode)
+	copy(hdr[72:], hashSpec)
+	binary.BigEndian.PutUint32(hdr[104:], payload)
+	binary.BigEndian.PutUint32(hdr[108:], uint32(keyBytes))
+	salt := random(32)
+	copy(hdr[112:], pbkdf2(h, mk, salt, iterations, 20))
+	copy(hdr[132:], salt)
+	binary.BigEndian.PutUint32(hdr[164:], iterations)
+	copy(hdr[168:], uuid)
+
+	// Keyslot areas are 4096 byte aligned after the first 4096 bytes
+	area := (keyBytes*stripes + 4095) / 4096 * 8
+	for i := 0; i < 8; i++ {
+		ks := hdr[208+48*i:]
+		binary.BigEndian.PutUint32(ks, 0xdead)
+		binary.BigEndian.PutUint32(ks[40:], uint32(8+i*area))
+		binary.BigEndian.PutUint32(ks[44:], stripes)
+	}
+	for _, s := range slots {
+		ks := hdr[208+48*s.id:]
+		salt := random(32)
+		binary.BigEndian.PutUint32(ks, 0x00ac71f3)
+		binary.BigEndian.PutUint32(ks[4:], iterations)
+		copy(ks[8:], salt)
+
+		material := afSplit(h, mk, stripes)
+		material = append(material, make([]byte, (len(material)+511)/512*512-len(material))...)
+		key := pbkdf2(h, []byte(s.passphrase), salt, iterations, keyBytes)
+		copy(img[(8+s.id*area)*512:], encrypt(spec, key, material, 512, 0))
+	}
+
+	copy(img[payload*512:], encrypt(spec, mk, data, 512, 0))
+	write(name, img)
+}
+
+// luks2 writes a LUKS2 volume as cryptsetup luksFormat --type luks2
+// --pbkdf pbkdf2 --sector-size 4096 --luks2-metadata-size 12k
+// --luks2-keyslots-size 256k, with an Argon2id keyslot 0, which is left
+// empty, and a PBKDF2 keyslot 1.
+func luks2(name, passphrase string) {
+	const (
+		hdrSize, keyslots, keyslotsSize = 16384, 32768, 262144
+		stripes, iterations, keyBytes   = 4000, 1000, 32
+		spec                            = "aes-xts-plain64"
+	)
+	mk := random(keyBytes)
+	data := plaintext(16 * 1024)
+	img := make([]byte, keyslots+keyslotsSize+len(data))
+
+	salt := random(32)
+	material := afSplit(sha256.New, mk, stripes)
+	key := pbkdf2(sha256.New, []byte(passphrase), salt, iterations, keyBytes)
+	material = append(material, make([]byte, (len(material)+511)/512*512-len(material))...)
+	copy(img[keyslots+keyslotsSize/2:], encrypt(spec, key, material, 512, 0))
+
+	digestSalt := random(32)
+	area := func(n int) map[string]interface{} {
+		return map[string]interface{}{
+			"type": "raw", "offset": strconv.Itoa(keyslots + n*keyslotsSize/2), "size": "131072",
+			"encryption": spec, "key_size": keyBytes,
+		}
+	}
+	af := map[string]interface{}{"type": "luks1", "stripes": stripes, "hash": "sha256"}
+	md := map[string]interface{}{
+		"keyslots": map[string]interface{}{
+			"0": map[string]interface{}{
+				"type": "luks2", "key_size": keyBytes, "af": af, "area": area(0),
+				"kdf": map[string]interface{}{
+					"type": "argon2id", "time": 4, "memory": 1048576, "cpus": 4, "salt": random(32),
+				},
+			},
+			"1": map[string]interface{}{
+				"type": "luks2", "key_size": keyBytes, "af": af, "area": area(1),
+				"kdf": map[string]interface{}{
+					"type": "pbkdf2", "hash": "sha256", "iterations": iterations, "salt": salt,
+				},
+			},
+		},
+		"tokens": map[string]interface{}{},
+		"segments": map[string]interface{}{
+			"0": map[string]interface{}{
+				"type": "crypt", "offset": strconv.Itoa(keyslots + keyslotsSize), "size": "dynamic",
+				"iv_tweak": "0", "encryption": spec, "sector_size": 4096,
+			},
+		},
+		"digests": map[string]interface{}{
+			"0": map[string]interface{}{
+				"type": "pbkdf2", "keyslots": []string{"0", "1"}, "segments": []string{"0"},
+				"hash": "sha256", "iterations": iterations, "salt": digestSalt,
+				"digest": pbkdf2(sha256.New, mk, digestSalt, iterations, 32),
+			},
+		},
+		"config": map[string]interface{}{
+			"json_size": strconv.Itoa(hdrSize - 4096), "keyslots_size": strconv.Itoa(keyslotsSize),
+		},
+	}
+	js, err := json.Marshal(md)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	hdrSalt := random(64)
+	for i, m := range []string{"LUKS\xba\xbe", "SKUL\xba\xbe"} {
+		hdr := img[i*hdrSize : (i+1)*hdrSize]
+		copy(hdr, m)
+		binary.BigEndian.PutUint16(hdr[6:], 2)
+		binary.BigEndian.PutUint64(hdr[8:], hdrSize)
+		binary.BigEndian.PutUint64(hdr[16:], 3)
+		copy(hdr[24:], "fixture")
+		copy(hdr[72:], "sha256")
+		copy(hdr[104:], hdrSalt)
+		copy(hdr[168:], "2f4b1c9e-7d3a-4e8b-a6f0-5c2d8e1b9a74")
+		binary.BigEndian.PutUint64(hdr[256:], uint64(i*hdrSize))
+		copy(hdr[4096:], js)
+		sum := sha256.Sum256(hdr)
+		copy(hdr[448:], sum[:])
+	}
+
+	copy(img[keyslots+keyslotsSize:], encrypt(spec, mk, data, 4096, 0))
+	write(name, img)
+}
+
+func main() {
+	luks1("luks1-aes-xts-plain64.img.gz", "8c6e9b7a-4e1f-4a0d-9c51-3b7f2a6d1e08", "aes", "xts-plain64", "sha256", sha256.New, 32,
+		[]slot1{{0, "luks1 passphrase"}})
+	luks1("luks1-aes-cbc-essiv.img.gz", "d41f7c2b-93a5-4b6e-8f10-6a2e5c9d7b31", "aes", "cbc-essiv:sha256", "sha1", sha1.New, 16,
+		[]slot1{{0, "first passphrase"}, {2, "second passphrase"}})
+	luks2("luks2-aes-xts-plain64.img.gz", "luks2 passphrase")
+}
@@ -0,0 +1,49 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mac
+
+import (
+	"hash"
+
+	"github.com/surendarchandra/crypto/cipher"
+	"github.com/surendarchandra/crypto/internal/gf128"
+)
+
+// NewCMAC returns AES-CMAC (SP 800-38B, RFC 4493) keyed by block, which
+// may be any AES key size with a CBC mode.
+func NewCMAC(block cipher.Block) (hash.Hash, error) {
+	d, err := newCBCMAC(block, BlockSize, 0x80)
+	if err != nil {
+		return nil, err
+	}
+
+	// The subkeys are the encryption of zero, doubled once and twice
+	var l [BlockSize]byte
+	if err := cipher.EncryptBlock(d.block, l[:], l[:]); err != nil {
+		return nil, err
+	}
+	d.k1 = gf128.Double(l)
+	d.k2 = gf128.Double(d.k1)
+
+	return d, nil
+}
@@ -0,0 +1,162 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package mac implements message authentication codes built on the
+// accelerated AES from package aes: AES-CMAC, AES-XCBC-MAC-96,
+// AES-XCBC-PRF-128 and raw CBC-MAC. Each is a hash.Hash; whole blocks go
+// through the CBC encryption in bulk, so long messages run at CBC speed.
+//
+// Like the Block they are built on, the MACs are not safe for concurrent
+// use.
+package mac
+
+import (
+	"crypto/subtle"
+	"errors"
+	"hash"
+
+	"github.com/surendarchandra/crypto/cipher"
+)
+
+// BlockSize is the block size of the MACs in bytes.
+const BlockSize = 16
+
+// cbcMAC is the CBC-MAC core that CMAC and XCBC specialise: the last
+// block is XORed with k1 if complete, else padded and XORed with k2.
+type cbcMAC struct {
+	block  cipher.Block
+	size   int
+	k1, k2 [BlockSize]byte
+	pad    byte
+
+	// x is the chaining value; buf holds up to a block that has not
+	// been chained, as the last block is treated differently
+	x   [BlockSize]byte
+	buf [BlockSize]byte
+	n   int
+
+	scratch [4096]byte
+}
+
+// newCBCMAC checks that block has the CBC mode, which XTS keys lack.
+func newCBCMAC(block cipher.Block, size int, pad byte) (*cbcMAC, error) {
+	if block.BlockSize() != BlockSize {
+		return nil, errors.New("mac: requires a 128-bit block cipher")
+	}
+
+	d := &cbcMAC{block: block, size: size, pad: pad}
+	if err := cipher.EncryptBlock(d.block, d.scratch[:BlockSize], d.scratch[:BlockSize]); err != nil {
+		return nil, errors.New("mac: not supported for this key size")
+	}
+
+	return d, nil
+}
+
+// NewCBCMAC returns the raw CBC-MAC of block, the last block of the CBC
+// encryption of the message with a zero IV. A partial last block is zero
+// padded. CBC-MAC is only secure for messages of one fixed length; use
+// CMAC otherwise.
+func NewCBCMAC(block cipher.Block) (hash.Hash, error) {
+	return newCBCMAC(block, BlockSize, 0)
+}
+
+// Verify reports whether tag is the MAC of the message written to h,
+// comparing in constant time.
+func Verify(h hash.Hash, tag []byte) bool {
+	return subtle.ConstantTimeCompare(h.Sum(nil), tag) == 1
+}
+
+// chain runs data, a whole number of blocks, through CBC from x. It
+// panics on a block error, as hash.Hash has no way to return one.
+func (d *cbcMAC) chain(data []byte) {
+	for len(data) > 0 {
+		n := len(data)
+		if n > len(d.scratch) {
+			n = len(d.scratch)
+		}
+		d.block.SetIV(d.x[:])
+		if err := d.block.Encrypt(d.scratch[:n], data[:n], cipher.ModeCBC); err != nil {
+			panic("mac: " + err.Error())
+		}
+		copy(d.x[:], d.scratch[n-BlockSize:n])
+		data = data[n:]
+	}
+}
+
+func (d *cbcMAC) Size() int {
+	return d.size
+}
+
+func (d *cbcMAC) BlockSize() int {
+	return BlockSize
+}
+
+func (d *cbcMAC) Reset() {
+	d.x = [BlockSize]byte{}
+	d.n = 0
+}
+
+func (d *cbcMAC) Write(p []byte) (int, error) {
+	written := len(p)
+
+	if d.n > 0 {
+		m := copy(d.buf[d.n:], p)
+		d.n += m
+		p = p[m:]
+		if len(p) == 0 {
+			return written, nil
+		}
+		d.chain(d.buf[:])
+		d.n = 0
+	}
+
+	// Keep back at least one byte, the last block may be complete
+	if len(p) > BlockSize {
+		full := (len(p) - 1) / BlockSize * BlockSize
+		d.chain(p[:full])
+		p = p[full:]
+	}
+	d.n = copy(d.buf[:], p)
+
+	return written, nil
+}
+
+func (d *cbcMAC) Sum(in []byte) []byte {
+	var last [BlockSize]byte
+
+	copy(last[:], d.buf[:d.n])
+	k := &d.k1
+	if d.n < BlockSize {
+		last[d.n] = d.pad
+		k = &d.k2
+	}
+	for i := range last {
+		last[i] ^= k[i]
+	}
+
+	d.block.SetIV(d.x[:])
+	if err := d.block.Encrypt(last[:], last[:], cipher.ModeCBC); err != nil {
+		panic("mac: " + err.Error())
+	}
+
+	return append(in, last[:d.size]...)
+}
@@ -0,0 +1,233 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mac_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"hash"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cipher"
+	"github.com/surendarchandra/crypto/mac"
+)
+
+func fromHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// seq returns the bytes 0, 1, ... n-1.
+func seq(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(i)
+	}
+	return b
+}
+
+// checkMAC compares the MAC of msg, written in one go and a byte at a
+// time, with want.
+func checkMAC(t *testing.T, name string, h hash.Hash, msg []byte, want string) {
+	t.Helper()
+
+	h.Reset()
+	h.Write(msg)
+	if got := hex.EncodeToString(h.Sum(nil)); got != want {
+		t.Errorf("%s: got %s, want %s", name, got, want)
+	}
+	if !mac.Verify(h, fromHex(want)) {
+		t.Errorf("%s: Verify failed", name)
+	}
+
+	h.Reset()
+	for i := range msg {
+		h.Write(msg[i : i+1])
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != want {
+		t.Errorf("%s: byte at a time got %s, want %s", name, got, want)
+	}
+}
+
+// RFC 4493 section 4 examples
+func TestCMAC(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	block, err := aes.NewCipher(fromHex("2b7e151628aed2a6abf7158809cf4f3c"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, err := mac.NewCMAC(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := fromHex("6bc1bee22e409f96e93d7e117393172aae2d8a571e03ac9c9eb76fac45af8e51" +
+		"30c81c46a35ce411e5fbc1191a0a52eff69f2445df4f9b17ad2b417be66c3710")
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{0, "bb1d6929e95937287fa37d129b756746"},
+		{16, "070a16b46b4d4144f79bdd9dd04a287c"},
+		{40, "dfa66747de9ae63030ca32611497c827"},
+		{64, "51f0bebf7e3b9d92fc49741779363cfe"},
+	}
+	for _, test := range tests {
+		checkMAC(t, "CMAC", h, msg[:test.n], test.want)
+	}
+
+	if mac.Verify(h, fromHex("51f0bebf7e3b9d92fc49741779363cff")) {
+		t.Error("Verify accepted a modified tag")
+	}
+}
+
+// RFC 3566 section 4.6 test cases, with key 000102030405060708090a0b0c0d0e0f
+func TestXCBCMAC96(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	h, err := mac.NewXCBCMAC96(seq(16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.Size() != mac.XCBCMAC96Size {
+		t.Errorf("Size %d", h.Size())
+	}
+
+	tests := []struct {
+		msg  []byte
+		want string
+	}{
+		{nil, "75f0251d528ac01c4573dfd5"},
+		{seq(3), "5b376580ae2f19afe7219cee"},
+		{seq(16), "d2a246fa349b68a79998a439"},
+		{seq(20), "47f51b4564966215b8985c63"},
+		{seq(32), "f54f0ec8d2b9f3d36807734b"},
+		{seq(34), "becbb3bccdb518a30677d548"},
+		{make([]byte, 1000), "f0dafee895db30253761103b"},
+	}
+	for _, test := range tests {
+		checkMAC(t, "XCBC-MAC-96", h, test.msg, test.want)
+	}
+
+	if _, err := mac.NewXCBCMAC96(seq(32)); err == nil {
+		t.Error("accepted a 32 byte key")
+	}
+}
+
+// RFC 4434 section 6 test cases, with message 000102...13
+func TestXCBCPRF128(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	tests := []struct {
+		key  []byte
+		want string
+	}{
+		{seq(16), "47f51b4564966215b8985c63055ed308"},
+		{seq(10), "0fa087af7d866e7653434e602fdde835"},
+		{append(seq(16), 0xed, 0xcb), "8cd3c93ae598a9803006ffb67c40e9e4"},
+	}
+	for _, test := range tests {
+		h, err := mac.NewXCBCPRF128(test.key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkMAC(t, "XCBC-PRF-128", h, seq(20), test.want)
+	}
+}
+
+func TestCBCMAC(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	key := seq(16)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, err := mac.NewCBCMAC(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The MAC is the last block of the CBC encryption, across several
+	// bulk chunks
+	msg := bytes.Repeat(seq(256), 40)
+	ct := make([]byte, len(msg))
+	iv := make([]byte, 16)
+	if err := cipher.NewCBCEncrypter(block, iv).CryptBlocks(ct, msg); err != nil {
+		t.Fatal(err)
+	}
+	checkMAC(t, "CBC-MAC", h, msg, hex.EncodeToString(ct[len(ct)-16:]))
+
+	// A partial last block is zero padded
+	h.Reset()
+	h.Write(msg[:20])
+	want := h.Sum(nil)
+	h.Reset()
+	h.Write(append(msg[:20:20], make([]byte, 12)...))
+	if got := h.Sum(nil); !bytes.Equal(got, want) {
+		t.Errorf("padded got %x, want %x", got, want)
+	}
+
+	// XTS keys have no CBC mode
+	xts, err := aes.NewCipher(make([]byte, 64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mac.NewCBCMAC(xts); err == nil {
+		t.Error("accepted an XTS-256 key")
+	}
+	if _, err := mac.NewCMAC(xts); err == nil {
+		t.Error("accepted an XTS-256 key")
+	}
+}
+
+// Sum must not change the state, so writing can continue after it.
+func TestSumContinues(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	h, err := mac.NewXCBCPRF128(seq(16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Write(seq(10))
+	h.Sum(nil)
+	h.Write(seq(20)[10:])
+	if got := hex.EncodeToString(h.Sum(nil)); got != "47f51b4564966215b8985c63055ed308" {
+		t.Errorf("got %s", got)
+	}
+}
@@ -0,0 +1,68 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mac_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/internal/wycheproof"
+	"github.com/surendarchandra/crypto/mac"
+)
+
+func TestWycheproofCMAC(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	var file wycheproof.MacFile
+	wycheproof.Load(t, "aes_cmac_test.json", &file)
+
+	tally := wycheproof.NewTally(t)
+	defer tally.Report()
+
+	for _, group := range file.TestGroups {
+		for _, tc := range group.Tests {
+			block, err := aes.NewCipher(tc.Key)
+			if err != nil {
+				tally.Skip(tc.Test)
+				continue
+			}
+			h, err := mac.NewCMAC(block)
+			if err != nil {
+				tally.Skip(tc.Test)
+				continue
+			}
+
+			h.Write(tc.Msg)
+			sum := h.Sum(nil)
+			if len(tc.Tag) > len(sum) {
+				tally.Skip(tc.Test)
+				continue
+			}
+			ok := bytes.Equal(sum[:len(tc.Tag)], tc.Tag)
+			tally.Check(tc.Test, ok, ok)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mac
+
+import (
+	"bytes"
+	"errors"
+	"hash"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cipher"
+)
+
+// XCBCMAC96Size is the size of an AES-XCBC-MAC-96 tag in bytes.
+const XCBCMAC96Size = 12
+
+// newXCBC derives the three XCBC keys from a 16 byte key (RFC 3566
+// section 4): K1 keys the CBC-MAC, K2 and K3 are XORed into a complete or
+// padded last block.
+func newXCBC(key []byte, size int) (hash.Hash, error) {
+	if len(key) != 16 {
+		return nil, errors.New("mac: XCBC key must be 16 bytes")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	d, err := newCBCMAC(block, size, 0x80)
+	if err != nil {
+		return nil, err
+	}
+
+	var k [3][BlockSize]byte
+	for i := range k {
+		copy(k[i][:], bytes.Repeat([]byte{byte(i + 1)}, BlockSize))
+		if err := cipher.EncryptBlock(block, k[i][:], k[i][:]); err != nil {
+			return nil, err
+		}
+	}
+
+	d.block, err = aes.NewCipher(k[0][:])
+	if err != nil {
+		return nil, err
+	}
+	d.k1, d.k2 = k[1], k[2]
+
+	return d, nil
+}
+
+// NewXCBCMAC96 returns AES-XCBC-MAC-96 (RFC 3566) for a 16 byte key, with
+// the tag truncated to 12 bytes as used by IPsec.
+func NewXCBCMAC96(key []byte) (hash.Hash, error) {
+	return newXCBC(key, XCBCMAC96Size)
+}
+
+// NewXCBCPRF128 returns AES-XCBC-PRF-128 (RFC 4434), the IKEv2 PRF, which
+// takes a key of any length: a shorter key is zero padded to 16 bytes
+// and a longer one is first hashed with a zero key.
+func NewXCBCPRF128(key []byte) (hash.Hash, error) {
+	var k [BlockSize]byte
+
+	if len(key) <= BlockSize {
+		copy(k[:], key)
+	} else {
+		h, err := newXCBC(k[:], BlockSize)
+		if err != nil {
+			return nil, err
+		}
+		h.Write(key)
+		copy(k[:], h.Sum(nil))
+	}
+
+	return newXCBC(k[:], BlockSize)
+}
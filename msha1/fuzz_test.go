@@ -0,0 +1,171 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package msha1_test
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"math/bits"
+	"testing"
+
+	"github.com/surendarchandra/crypto/msha1"
+)
+
+// The multi-hash SHA-1 of ISA-L (mh_sha1_ref.c) runs 16 SHA-1 states over
+// interleaved 4-byte words of each 1024-byte block, pads the message to a
+// whole block as SHA-1 does, and returns the SHA-1 of the 16 segment
+// digests; refSum is that construction in Go.
+const (
+	refSegs      = 16
+	refBlockSize = refSegs * msha1.BlockSize
+)
+
+// refBlock runs the SHA-1 compression of each segment over one block:
+// word w of segment s is the big-endian dword w*16 + s of the block.
+func refBlock(h *[5][refSegs]uint32, block []byte) {
+	for s := 0; s < refSegs; s++ {
+		var w [80]uint32
+		for i := 0; i < 16; i++ {
+			w[i] = binary.BigEndian.Uint32(block[(i*refSegs+s)*4:])
+		}
+		for i := 16; i < 80; i++ {
+			w[i] = bits.RotateLeft32(w[i-3]^w[i-8]^w[i-14]^w[i-16], 1)
+		}
+
+		a, b, c, d, e := h[0][s], h[1][s], h[2][s], h[3][s], h[4][s]
+		for i := 0; i < 80; i++ {
+			var f, k uint32
+			switch {
+			case i < 20:
+				f, k = b&c|^b&d, 0x5a827999
+			case i < 40:
+				f, k = b^c^d, 0x6ed9eba1
+			case i < 60:
+				f, k = b&c|b&d|c&d, 0x8f1bbcdc
+			default:
+				f, k = b^c^d, 0xca62c1d6
+			}
+			t := bits.RotateLeft32(a, 5) + f + e + w[i] + k
+			a, b, c, d, e = t, a, bits.RotateLeft32(b, 30), c, d
+		}
+		h[0][s] += a
+		h[1][s] += b
+		h[2][s] += c
+		h[3][s] += d
+		h[4][s] += e
+	}
+}
+
+func refSum(data []byte) [msha1.Size]byte {
+	var h [5][refSegs]uint32
+	for s := 0; s < refSegs; s++ {
+		h[0][s], h[1][s], h[2][s], h[3][s], h[4][s] = 0x67452301, 0xefcdab89, 0x98badcfe, 0x10325476, 0xc3d2e1f0
+	}
+
+	n := len(data)
+	for ; len(data) >= refBlockSize; data = data[refBlockSize:] {
+		refBlock(&h, data)
+	}
+
+	size := refBlockSize
+	if len(data)+1 > refBlockSize-8 {
+		size *= 2
+	}
+	tail := make([]byte, size)
+	copy(tail, data)
+	tail[len(data)] = 0x80
+	binary.BigEndian.PutUint64(tail[len(tail)-8:], uint64(n)*8)
+	for ; len(tail) > 0; tail = tail[refBlockSize:] {
+		refBlock(&h, tail)
+	}
+
+	// The segment digests are hashed as ISA-L stores them, host order
+	// words, each word of all segments in turn; the result is also
+	// returned as host order words.
+	var digests [5 * refSegs * 4]byte
+	for i := 0; i < 5; i++ {
+		for s := 0; s < refSegs; s++ {
+			binary.LittleEndian.PutUint32(digests[(i*refSegs+s)*4:], h[i][s])
+		}
+	}
+	sum := sha1.Sum(digests[:])
+	for i := 0; i < msha1.Size; i += 4 {
+		binary.LittleEndian.PutUint32(sum[i:], binary.BigEndian.Uint32(sum[i:]))
+	}
+
+	return sum
+}
+
+// FuzzMSHA1 checks the one shot Sum and the streaming hash.Hash against
+// refSum: any split of the input across Write calls must give the same
+// digest, and Sum must neither modify the running state nor the prefix it
+// appends to.
+func FuzzMSHA1(f *testing.F) {
+	f.Add([]byte{}, uint8(1))
+	f.Add(make([]byte, 1024), uint8(64))
+	// Padding that spills into a second block
+	f.Add(make([]byte, 1016), uint8(100))
+	f.Add(bytes.Repeat([]byte("msha1"), 1000), uint8(255))
+
+	f.Fuzz(func(t *testing.T, data []byte, chunk uint8) {
+		want := refSum(data)
+		if got := msha1.Sum(data); got != want {
+			t.Fatalf("Sum\nhave %x\nwant %x", got, want)
+		}
+
+		step := int(chunk)
+		if step == 0 {
+			step = 1
+		}
+
+		h := msha1.New()
+		for i := 0; i < len(data); i += step {
+			end := i + step
+			if end > len(data) {
+				end = len(data)
+			}
+			if n, err := h.Write(data[i:end]); n != end-i || err != nil {
+				t.Fatalf("Write(%d bytes) = %d, %v", end-i, n, err)
+			}
+		}
+
+		prefix := []byte("prefix")
+		got := h.Sum(append([]byte(nil), prefix...))
+		if !bytes.Equal(got[:len(prefix)], prefix) {
+			t.Fatalf("Sum clobbered prefix: %x", got)
+		}
+		if !bytes.Equal(got[len(prefix):], want[:]) {
+			t.Fatalf("streaming digest\nhave %x\nwant %x", got[len(prefix):], want)
+		}
+		if again := h.Sum(nil); !bytes.Equal(again, want[:]) {
+			t.Fatalf("second Sum\nhave %x\nwant %x", again, want)
+		}
+
+		h.Reset()
+		h.Write(data)
+		if got := h.Sum(nil); !bytes.Equal(got, want[:]) {
+			t.Fatalf("digest after Reset\nhave %x\nwant %x", got, want)
+		}
+	})
+}
@@ -0,0 +1,242 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package siv implements AES-SIV, RFC 5297: deterministic authenticated
+// encryption. The same plaintext and associated data always give the
+// same ciphertext, which suits key wrapping and equality searchable
+// fields, and a repeated nonce in the AEAD form only reveals repeated
+// messages.
+package siv
+
+import (
+	"crypto/subtle"
+	"errors"
+	"hash"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cipher"
+	"github.com/surendarchandra/crypto/internal/alias"
+	"github.com/surendarchandra/crypto/internal/ctrmode"
+	"github.com/surendarchandra/crypto/internal/gf128"
+	"github.com/surendarchandra/crypto/mac"
+)
+
+// TagSize is the size of the synthetic IV prepended to the ciphertext.
+const TagSize = aes.BlockSize
+
+// maxComponents is the limit on S2V inputs, associated data and
+// plaintext together (RFC 5297 section 7).
+const maxComponents = 126
+
+var errOpen = errors.New("siv: message authentication failed")
+
+// SIV is AES-SIV with a given key. Like the Block it is built on, it is
+// not safe for concurrent use.
+type SIV struct {
+	mac hash.Hash
+	ctr cipher.Block
+}
+
+// New returns AES-SIV for a 32, 48 or 64 byte key: the first half keys
+// the S2V CMAC and the second half CTR mode, giving AES-SIV-CMAC-256,
+// -384 and -512.
+func New(key []byte) (*SIV, error) {
+	if len(key) != 32 && len(key) != 48 && len(key) != 64 {
+		return nil, errors.New("siv: key must be 32, 48 or 64 bytes")
+	}
+
+	macBlock, err := aes.NewCipher(key[:len(key)/2])
+	if err != nil {
+		return nil, err
+	}
+	ctrBlock, err := aes.NewCipher(key[len(key)/2:])
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := mac.NewCMAC(macBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SIV{mac: h, ctr: ctrBlock}, nil
+}
+
+// cmac returns the CMAC of the concatenation of parts.
+func (s *SIV) cmac(parts ...[]byte) [aes.BlockSize]byte {
+	var sum [aes.BlockSize]byte
+
+	s.mac.Reset()
+	for _, p := range parts {
+		s.mac.Write(p)
+	}
+	s.mac.Sum(sum[:0])
+
+	return sum
+}
+
+func xorBlock(dst, src *[aes.BlockSize]byte) {
+	for i := range dst {
+		dst[i] ^= src[i]
+	}
+}
+
+// s2v is the vector MAC of RFC 5297 section 2.4; the plaintext is the
+// last component.
+func (s *SIV) s2v(plaintext []byte, additionalData [][]byte) [aes.BlockSize]byte {
+	var zero [aes.BlockSize]byte
+	d := s.cmac(zero[:])
+
+	for _, ad := range additionalData {
+		d = gf128.Double(d)
+		m := s.cmac(ad)
+		xorBlock(&d, &m)
+	}
+
+	if len(plaintext) >= aes.BlockSize {
+		// xorend: D into the last block of the plaintext
+		var tail [aes.BlockSize]byte
+		copy(tail[:], plaintext[len(plaintext)-aes.BlockSize:])
+		xorBlock(&tail, &d)
+		return s.cmac(plaintext[:len(plaintext)-aes.BlockSize], tail[:])
+	}
+
+	d = gf128.Double(d)
+	var t [aes.BlockSize]byte
+	copy(t[:], plaintext)
+	t[len(plaintext)] = 0x80
+	xorBlock(&d, &t)
+
+	return s.cmac(d[:])
+}
+
+// ctrCrypt runs CTR mode from the synthetic IV with bits 31 and 63
+// cleared, a 128-bit big endian counter.
+func (s *SIV) ctrCrypt(dst, src []byte, v [aes.BlockSize]byte) error {
+	counter := v
+	counter[8] &= 0x7f
+	counter[12] &= 0x7f
+
+	encrypt := func(dst, src []byte) error { return cipher.EncryptBlocks(s.ctr, dst, src) }
+	return ctrmode.XORKeyStream(encrypt, dst, src, counter[:], ctrmode.IncrementBE)
+}
+
+// Seal encrypts and authenticates plaintext along with any number of
+// associated data components, and appends the synthetic IV followed by
+// the ciphertext to dst. For nonce based use, pass the nonce as the last
+// component.
+func (s *SIV) Seal(dst, plaintext []byte, additionalData ...[]byte) []byte {
+	if len(additionalData) >= maxComponents {
+		panic("siv: too many associated data components")
+	}
+
+	v := s.s2v(plaintext, additionalData)
+
+	ret, out := alias.SliceForAppend(dst, TagSize+len(plaintext))
+	// The ciphertext lands TagSize bytes after where plaintext[:0] as
+	// dst would put it, so an overlapping plaintext is copied first.
+	if alias.AnyOverlap(out, plaintext) {
+		plaintext = append([]byte(nil), plaintext...)
+	}
+	if err := s.ctrCrypt(out[TagSize:], plaintext, v); err != nil {
+		panic("siv: " + err.Error())
+	}
+	copy(out, v[:])
+
+	return ret
+}
+
+// Open decrypts and authenticates a ciphertext from Seal with the same
+// associated data components and appends the plaintext to dst.
+func (s *SIV) Open(dst, ciphertext []byte, additionalData ...[]byte) ([]byte, error) {
+	if len(ciphertext) < TagSize || len(additionalData) >= maxComponents {
+		return nil, errOpen
+	}
+
+	var v [aes.BlockSize]byte
+	copy(v[:], ciphertext)
+	ciphertext = ciphertext[TagSize:]
+
+	ret, out := alias.SliceForAppend(dst, len(ciphertext))
+	if err := s.ctrCrypt(out, ciphertext, v); err != nil {
+		return nil, err
+	}
+
+	t := s.s2v(out, additionalData)
+	if subtle.ConstantTimeCompare(t[:], v[:]) != 1 {
+		for i := range out {
+			out[i] = 0
+		}
+		return nil, errOpen
+	}
+
+	return ret, nil
+}
+
+type sivAEAD struct {
+	siv       *SIV
+	nonceSize int
+}
+
+// NewAEAD returns AES-SIV with the AEAD interface, as AEAD_AES_SIV_CMAC_256
+// and friends in RFC 5297 section 6: the additional data and then the
+// nonce are the S2V components, and the synthetic IV comes first in the
+// output. A nonceSize of zero gives deterministic encryption.
+func NewAEAD(key []byte, nonceSize int) (cipher.AEAD, error) {
+	if nonceSize < 0 {
+		return nil, errors.New("siv: invalid nonce size")
+	}
+
+	s, err := New(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sivAEAD{siv: s, nonceSize: nonceSize}, nil
+}
+
+func (a *sivAEAD) NonceSize() int {
+	return a.nonceSize
+}
+
+func (*sivAEAD) Overhead() int {
+	return TagSize
+}
+
+func (a *sivAEAD) components(nonce, additionalData []byte) [][]byte {
+	if len(nonce) != a.nonceSize {
+		panic("siv: incorrect nonce length given to AES-SIV")
+	}
+	if a.nonceSize == 0 {
+		return [][]byte{additionalData}
+	}
+
+	return [][]byte{additionalData, nonce}
+}
+
+func (a *sivAEAD) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	return a.siv.Seal(dst, plaintext, a.components(nonce, additionalData)...)
+}
+
+func (a *sivAEAD) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	return a.siv.Open(dst, ciphertext, a.components(nonce, additionalData)...)
+}
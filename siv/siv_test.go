@@ -0,0 +1,143 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package siv_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/siv"
+)
+
+func fromHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// RFC 5297 appendix A
+var sivTests = []struct {
+	key, plaintext, result string
+	ad                     []string
+}{
+	{
+		"fffefdfcfbfaf9f8f7f6f5f4f3f2f1f0f0f1f2f3f4f5f6f7f8f9fafbfcfdfeff",
+		"112233445566778899aabbccddee",
+		"85632d07c6e8f37f950acd320a2ecc9340c02b9690c4dc04daef7f6afe5c",
+		[]string{"101112131415161718191a1b1c1d1e1f2021222324252627"},
+	},
+	{
+		"7f7e7d7c7b7a79787776757473727170404142434445464748494a4b4c4d4e4f",
+		"7468697320697320736f6d6520706c61696e7465787420746f20656e6372797074207573696e67205349562d414553",
+		"7bdb6e3b432667eb06f4d14bff2fbd0fcb900f2fddbe404326601965c889bf17dba77ceb094fa663b7a3f748ba8af829ea64ad544a272e9c485b62a3fd5c0d",
+		[]string{
+			"00112233445566778899aabbccddeeffdeaddadadeaddadaffeeddccbbaa99887766554433221100",
+			"102030405060708090a0",
+			"09f911029d74e35bd84156c5635688c0",
+		},
+	},
+}
+
+func TestSIV(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	for i, tc := range sivTests {
+		s, err := siv.New(fromHex(tc.key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ad [][]byte
+		for _, a := range tc.ad {
+			ad = append(ad, fromHex(a))
+		}
+		pt := fromHex(tc.plaintext)
+
+		ct := s.Seal(nil, pt, ad...)
+		if got := hex.EncodeToString(ct); got != tc.result {
+			t.Errorf("#%d: got %s, want %s", i, got, tc.result)
+			continue
+		}
+
+		got, err := s.Open(nil, ct, ad...)
+		if err != nil {
+			t.Errorf("#%d: Open failed: %v", i, err)
+		} else if !bytes.Equal(got, pt) {
+			t.Errorf("#%d: got %x, want %x", i, got, pt)
+		}
+
+		// Sealing in place moves the plaintext up by the tag
+		buf := make([]byte, len(pt), len(pt)+siv.TagSize)
+		copy(buf, pt)
+		if inPlace := s.Seal(buf[:0], buf, ad...); !bytes.Equal(inPlace, ct) {
+			t.Errorf("#%d: in place Seal differs", i)
+		}
+
+		// Dropping or reordering components must fail
+		if len(ad) > 1 {
+			if _, err := s.Open(nil, ct, ad[1:]...); err == nil {
+				t.Errorf("#%d: opened with a component missing", i)
+			}
+			ad[0], ad[1] = ad[1], ad[0]
+			if _, err := s.Open(nil, ct, ad...); err == nil {
+				t.Errorf("#%d: opened with components swapped", i)
+			}
+		}
+	}
+
+	for _, n := range []int{16, 24, 40, 128} {
+		if _, err := siv.New(make([]byte, n)); err == nil {
+			t.Errorf("accepted a %d byte key", n)
+		}
+	}
+}
+
+func TestSIVAEAD(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	key := fromHex(sivTests[1].key)
+	aead, err := siv.NewAEAD(key, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := siv.New(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nonce, ad, pt := fromHex(sivTests[1].ad[2]), []byte("header"), []byte("message")
+	ct := aead.Seal(nil, nonce, pt, ad)
+	if want := s.Seal(nil, pt, ad, nonce); !bytes.Equal(ct, want) {
+		t.Errorf("AEAD output %x, want %x", ct, want)
+	}
+	if got, err := aead.Open(nil, nonce, ct, ad); err != nil || !bytes.Equal(got, pt) {
+		t.Errorf("Open: %x, %v", got, err)
+	}
+}
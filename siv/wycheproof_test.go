@@ -0,0 +1,95 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package siv_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/internal/wycheproof"
+	"github.com/surendarchandra/crypto/siv"
+)
+
+func TestWycheproofSIV(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	var file wycheproof.DaeadFile
+	wycheproof.Load(t, "aes_siv_cmac_test.json", &file)
+
+	tally := wycheproof.NewTally(t)
+	defer tally.Report()
+
+	for _, group := range file.TestGroups {
+		for _, tc := range group.Tests {
+			s, err := siv.New(tc.Key)
+			if err != nil {
+				tally.Skip(tc.Test)
+				continue
+			}
+
+			match := true
+			if tc.Result == "valid" {
+				match = bytes.Equal(s.Seal(nil, tc.Msg, tc.AAD), tc.Ct)
+			}
+
+			pt, err := s.Open(nil, tc.Ct, tc.AAD)
+			tally.Check(tc.Test, err == nil, match && bytes.Equal(pt, tc.Msg))
+		}
+	}
+}
+
+// The AEAD file splits the output into ct and tag, but AES-SIV puts the
+// tag (the synthetic IV) first.
+func TestWycheproofSIVAEAD(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	var file wycheproof.AEADFile
+	wycheproof.Load(t, "aead_aes_siv_cmac_test.json", &file)
+
+	tally := wycheproof.NewTally(t)
+	defer tally.Report()
+
+	for _, group := range file.TestGroups {
+		for _, tc := range group.Tests {
+			aead, err := siv.NewAEAD(tc.Key, group.IVSize/8)
+			if err != nil {
+				tally.Skip(tc.Test)
+				continue
+			}
+
+			want := append(append([]byte(nil), tc.Tag...), tc.Ct...)
+			match := true
+			if tc.Result == "valid" {
+				match = bytes.Equal(aead.Seal(nil, tc.IV, tc.Msg, tc.AAD), want)
+			}
+
+			pt, err := aead.Open(nil, tc.IV, want, tc.AAD)
+			tally.Check(tc.Test, err == nil, match && bytes.Equal(pt, tc.Msg))
+		}
+	}
+}
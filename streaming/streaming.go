@@ -0,0 +1,333 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package streaming implements segmented authenticated encryption of
+// streams with AES-GCM, in the style of the STREAM construction of Tink's
+// streaming AEAD and age's payload.
+//
+// A stream starts with a header of a random salt, as long as the key, and
+// a random 7 byte nonce prefix. The segment key is HKDF-SHA-256 of the
+// key with that salt and the associated data as info, so every stream
+// has its own key. The plaintext is cut into segments of the segment
+// size, the last of which may be shorter (and is empty only for an empty
+// stream). Segment i is sealed with AES-GCM under the nonce
+//
+//	nonce prefix || uint32(i) big-endian || 1 for the last segment, else 0
+//
+// so segments cannot be reordered, dropped or moved between streams, and
+// a stream cut at a segment boundary fails because its final segment is
+// not marked last. Each segment adds a 16 byte tag.
+package streaming
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cipher"
+	"github.com/surendarchandra/crypto/internal/hkdf"
+)
+
+const (
+	// DefaultSegmentSize is the plaintext size of a segment, but for the
+	// last, that suits most streams.
+	DefaultSegmentSize = 64 << 10
+
+	// MinSegmentSize and MaxSegmentSize bound the segment size.
+	MinSegmentSize = 16
+	MaxSegmentSize = 1 << 24
+
+	// NoncePrefixSize is the size of the random nonce prefix in the
+	// header.
+	NoncePrefixSize = 7
+
+	// TagSize is the size of the GCM tag added to each segment.
+	TagSize = 16
+
+	// maxSegments is the number of segment counters
+	maxSegments = 1 << 32
+)
+
+var (
+	errOpen      = errors.New("streaming: message authentication failed")
+	errClosed    = errors.New("streaming: write to closed writer")
+	errTooLong   = errors.New("streaming: too many segments")
+	errTruncated = errors.New("streaming: header truncated")
+)
+
+// A StreamingAEAD encrypts and decrypts streams under one key. It is safe
+// for concurrent use; each writer or reader it returns is not.
+type StreamingAEAD struct {
+	key         []byte
+	segmentSize int
+}
+
+// New returns a StreamingAEAD for a 16 byte (AES-128) or 32 byte
+// (AES-256) key, cutting streams into segments of segmentSize plaintext
+// bytes, MinSegmentSize to MaxSegmentSize. Both sides must use the same
+// segment size.
+func New(key []byte, segmentSize int) (*StreamingAEAD, error) {
+	if len(key) != 16 && len(key) != 32 {
+		return nil, errors.New("streaming: key must be 16 or 32 bytes")
+	}
+	if segmentSize < MinSegmentSize || segmentSize > MaxSegmentSize {
+		return nil, errors.New("streaming: invalid segment size")
+	}
+
+	return &StreamingAEAD{key: append([]byte(nil), key...), segmentSize: segmentSize}, nil
+}
+
+// HeaderSize returns the size of the stream header: the salt and the
+// nonce prefix.
+func (s *StreamingAEAD) HeaderSize() int {
+	return len(s.key) + NoncePrefixSize
+}
+
+// CiphertextSize returns the size of the encrypted stream for a
+// plaintext of n bytes.
+func (s *StreamingAEAD) CiphertextSize(n int64) int64 {
+	segments := (n + int64(s.segmentSize) - 1) / int64(s.segmentSize)
+	if segments == 0 {
+		segments = 1
+	}
+	return int64(s.HeaderSize()) + n + segments*TagSize
+}
+
+// segmentAEAD returns the GCM under the segment key for a stream header.
+func (s *StreamingAEAD) segmentAEAD(salt, associatedData []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(hkdf.Key(s.key, salt, associatedData, len(s.key)))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// segmentNonce returns the nonce of segment i.
+func segmentNonce(nonce, prefix []byte, i uint64, last bool) {
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint32(nonce[NoncePrefixSize:], uint32(i))
+	nonce[len(nonce)-1] = 0
+	if last {
+		nonce[len(nonce)-1] = 1
+	}
+}
+
+type encryptingWriter struct {
+	w      io.Writer
+	aead   cipher.AEAD
+	prefix [NoncePrefixSize]byte
+	nonce  [12]byte
+	index  uint64
+
+	buf, out []byte
+	n        int
+	err      error
+}
+
+// NewEncryptingWriter writes the stream header to w and returns a writer
+// that encrypts to w, bound to associatedData. Close must be called to
+// write the last segment; it does not close w.
+func (s *StreamingAEAD) NewEncryptingWriter(w io.Writer, associatedData []byte) (io.WriteCloser, error) {
+	header := make([]byte, s.HeaderSize())
+	if _, err := rand.Read(header); err != nil {
+		return nil, err
+	}
+
+	aead, err := s.segmentAEAD(header[:len(s.key)], associatedData)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	e := &encryptingWriter{
+		w:    w,
+		aead: aead,
+		buf:  make([]byte, s.segmentSize),
+		out:  make([]byte, 0, s.segmentSize+TagSize),
+	}
+	copy(e.prefix[:], header[len(s.key):])
+
+	return e, nil
+}
+
+// seal encrypts and writes the buffered segment.
+func (e *encryptingWriter) seal(last bool) error {
+	if e.index == maxSegments {
+		return errTooLong
+	}
+
+	segmentNonce(e.nonce[:], e.prefix[:], e.index, last)
+	ct := e.aead.Seal(e.out[:0], e.nonce[:], e.buf[:e.n], nil)
+	e.index++
+	e.n = 0
+
+	_, err := e.w.Write(ct)
+	return err
+}
+
+// Write buffers p, sealing a segment whenever the buffer is full and more
+// data follows, as the last segment is only known at Close.
+func (e *encryptingWriter) Write(p []byte) (int, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+
+	written := 0
+	for len(p) > 0 {
+		if e.n == len(e.buf) {
+			if e.err = e.seal(false); e.err != nil {
+				return written, e.err
+			}
+		}
+
+		n := copy(e.buf[e.n:], p)
+		e.n += n
+		written += n
+		p = p[n:]
+	}
+
+	return written, nil
+}
+
+// Close seals the last segment.
+func (e *encryptingWriter) Close() error {
+	if e.err != nil {
+		if e.err == errClosed {
+			return nil
+		}
+		return e.err
+	}
+
+	if e.err = e.seal(true); e.err != nil {
+		return e.err
+	}
+	e.err = errClosed
+
+	return nil
+}
+
+type decryptingReader struct {
+	r      io.Reader
+	aead   cipher.AEAD
+	prefix [NoncePrefixSize]byte
+	nonce  [12]byte
+	index  uint64
+
+	// in holds the ciphertext read ahead, a segment and one byte, to
+	// tell whether a segment is the last one; plain is what remains of
+	// the opened segment in out
+	in, out []byte
+	fill    int
+	plain   []byte
+	err     error
+}
+
+// NewDecryptingReader reads the stream header from r and returns a
+// reader of the plaintext, which must have been encrypted with
+// associatedData. The reader returns an error, rather than io.EOF, for a
+// stream that was modified, reordered or truncated; plaintext from a
+// segment is only returned once the segment is authenticated.
+func (s *StreamingAEAD) NewDecryptingReader(r io.Reader, associatedData []byte) (io.Reader, error) {
+	header := make([]byte, s.HeaderSize())
+	if _, err := io.ReadFull(r, header); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, errTruncated
+		}
+		return nil, err
+	}
+
+	aead, err := s.segmentAEAD(header[:len(s.key)], associatedData)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &decryptingReader{
+		r:    r,
+		aead: aead,
+		in:   make([]byte, s.segmentSize+TagSize+1),
+		out:  make([]byte, 0, s.segmentSize),
+	}
+	copy(d.prefix[:], header[len(s.key):])
+
+	return d, nil
+}
+
+// next reads and opens the next segment into plain.
+func (d *decryptingReader) next() error {
+	n, err := io.ReadFull(d.r, d.in[d.fill:])
+	d.fill += n
+	last := false
+	switch err {
+	case nil:
+	case io.EOF, io.ErrUnexpectedEOF:
+		last = true
+	default:
+		return err
+	}
+
+	size := d.fill
+	if !last {
+		size--
+	}
+	if size < TagSize || d.index == maxSegments {
+		return errOpen
+	}
+
+	segmentNonce(d.nonce[:], d.prefix[:], d.index, last)
+	plain, err := d.aead.Open(d.out[:0], d.nonce[:], d.in[:size], nil)
+	if err != nil {
+		return errOpen
+	}
+	d.index++
+	d.plain = plain
+
+	// Keep the byte read ahead
+	d.fill = 0
+	if last {
+		d.err = io.EOF
+	} else {
+		d.in[0] = d.in[size]
+		d.fill = 1
+	}
+
+	return nil
+}
+
+func (d *decryptingReader) Read(p []byte) (int, error) {
+	for len(d.plain) == 0 {
+		if d.err != nil {
+			return 0, d.err
+		}
+		if err := d.next(); err != nil {
+			d.err = err
+			return 0, err
+		}
+	}
+
+	n := copy(p, d.plain)
+	d.plain = d.plain[n:]
+	return n, nil
+}
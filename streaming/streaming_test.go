@@ -0,0 +1,275 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package streaming_test
+
+import (
+	"bytes"
+	gaes "crypto/aes"
+	gcipher "crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"math/rand"
+	"testing"
+	"testing/iotest"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/streaming"
+)
+
+// encrypt returns the encrypted stream of msg, written in pieces of up
+// to chunk bytes.
+func encrypt(t *testing.T, s *streaming.StreamingAEAD, msg, ad []byte, chunk int) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w, err := s.NewEncryptingWriter(&buf, ad)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for p := msg; len(p) > 0; {
+		n := chunk
+		if n > len(p) {
+			n = len(p)
+		}
+		if m, err := w.Write(p[:n]); err != nil || m != n {
+			t.Fatalf("Write: %d, %v", m, err)
+		}
+		p = p[n:]
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func decrypt(s *streaming.StreamingAEAD, ct, ad []byte) ([]byte, error) {
+	r, err := s.NewDecryptingReader(bytes.NewReader(ct), ad)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+// reference decrypts a stream with crypto/aes and crypto/cipher, following
+// the package documentation.
+func reference(key, ct, ad []byte, segmentSize int) ([]byte, bool) {
+	salt, prefix := ct[:len(key)], ct[len(key):len(key)+7]
+	ct = ct[len(key)+7:]
+
+	extract := hmac.New(sha256.New, salt)
+	extract.Write(key)
+	expand := hmac.New(sha256.New, extract.Sum(nil))
+	expand.Write(ad)
+	expand.Write([]byte{1})
+	block, _ := gaes.NewCipher(expand.Sum(nil)[:len(key)])
+	g, _ := gcipher.NewGCM(block)
+
+	var plain []byte
+	for i := uint32(0); ; i++ {
+		n := segmentSize + 16
+		last := len(ct) <= n
+		if last {
+			n = len(ct)
+		}
+
+		nonce := make([]byte, 12)
+		copy(nonce, prefix)
+		binary.BigEndian.PutUint32(nonce[7:], i)
+		if last {
+			nonce[11] = 1
+		}
+		p, err := g.Open(nil, nonce, ct[:n], nil)
+		if err != nil {
+			return nil, false
+		}
+		plain = append(plain, p...)
+		ct = ct[n:]
+		if last {
+			return plain, true
+		}
+	}
+}
+
+func TestStreaming(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	r := rand.New(rand.NewSource(1))
+	ad := []byte("file name")
+	for _, keySize := range []int{16, 32} {
+		key := make([]byte, keySize)
+		r.Read(key)
+
+		for _, segmentSize := range []int{16, 100, 4096} {
+			s, err := streaming.New(key, segmentSize)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, n := range []int{0, 1, segmentSize - 1, segmentSize, segmentSize + 1, 3 * segmentSize, 10000} {
+				msg := make([]byte, n)
+				r.Read(msg)
+
+				for _, chunk := range []int{1, 7, segmentSize, 1 << 20} {
+					ct := encrypt(t, s, msg, ad, chunk)
+					if int64(len(ct)) != s.CiphertextSize(int64(n)) {
+						t.Errorf("key %d, segment %d, %d bytes: %d bytes of ciphertext, CiphertextSize %d",
+							keySize, segmentSize, n, len(ct), s.CiphertextSize(int64(n)))
+					}
+					if pt, ok := reference(key, ct, ad, segmentSize); !ok || !bytes.Equal(pt, msg) {
+						t.Errorf("key %d, segment %d, %d bytes: reference decryption failed", keySize, segmentSize, n)
+					}
+					if pt, err := decrypt(s, ct, ad); err != nil || !bytes.Equal(pt, msg) {
+						t.Errorf("key %d, segment %d, %d bytes: round trip failed: %v", keySize, segmentSize, n, err)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestStreamingShortReads(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	s, err := streaming.New(make([]byte, 16), 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := bytes.Repeat([]byte("short reads "), 50)
+	ct := encrypt(t, s, msg, nil, len(msg))
+
+	for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+		r, err := s.NewDecryptingReader(wrap(bytes.NewReader(ct)), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := iotest.TestReader(r, msg); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestStreamingTampering(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	const segmentSize = 32
+	key := make([]byte, 16)
+	s, err := streaming.New(key, segmentSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := bytes.Repeat([]byte{0x5a}, 4*segmentSize+5)
+	ad := []byte("ad")
+	ct := encrypt(t, s, msg, ad, len(msg))
+
+	header := s.HeaderSize()
+	seg := func(i int) []byte {
+		start := header + i*(segmentSize+16)
+		end := start + segmentSize + 16
+		if end > len(ct) {
+			end = len(ct)
+		}
+		return ct[start:end]
+	}
+	join := func(parts ...[]byte) []byte {
+		var b []byte
+		for _, p := range parts {
+			b = append(b, p...)
+		}
+		return b
+	}
+
+	for _, test := range []struct {
+		name string
+		ct   []byte
+	}{
+		{"truncated at a segment boundary", ct[:header+4*(segmentSize+16)]},
+		{"truncated within a segment", ct[:len(ct)-1]},
+		{"last segment dropped", join(ct[:header], seg(0), seg(1), seg(2), seg(3))},
+		{"segments reordered", join(ct[:header], seg(1), seg(0), seg(2), seg(3), seg(4))},
+		{"segment repeated", join(ct[:header], seg(0), seg(0), seg(1), seg(2), seg(3), seg(4))},
+		{"trailing data", join(ct, []byte{0})},
+		{"header only", ct[:header]},
+		{"header truncated", ct[:header-1]},
+		{"salt modified", join([]byte{ct[0] ^ 1}, ct[1:])},
+		{"nonce prefix modified", join(ct[:header-1], []byte{ct[header-1] ^ 1}, ct[header:])},
+		{"ciphertext modified", join(ct[:header+3], []byte{ct[header+3] ^ 1}, ct[header+4:])},
+	} {
+		if pt, err := decrypt(s, test.ct, ad); err == nil {
+			t.Errorf("%s: decrypted %d bytes", test.name, len(pt))
+		}
+	}
+
+	if _, err := decrypt(s, ct, []byte("other ad")); err == nil {
+		t.Error("decrypted with other associated data")
+	}
+	other, _ := streaming.New(make([]byte, 32), segmentSize)
+	if _, err := decrypt(other, ct, ad); err == nil {
+		t.Error("decrypted with another key")
+	}
+	bigger, _ := streaming.New(key, 2*segmentSize)
+	if _, err := decrypt(bigger, ct, ad); err == nil {
+		t.Error("decrypted with another segment size")
+	}
+
+	// A stream from another writer with the same key does not mix in
+	second := encrypt(t, s, msg, ad, len(msg))
+	if _, err := decrypt(s, join(ct[:header], seg(0), second[header+segmentSize+16:]), ad); err == nil {
+		t.Error("decrypted segments of two streams")
+	}
+}
+
+func TestStreamingParameters(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	for _, p := range [][2]int{{24, 4096}, {16, 15}, {32, streaming.MaxSegmentSize + 1}} {
+		if _, err := streaming.New(make([]byte, p[0]), p[1]); err == nil {
+			t.Errorf("accepted key size %d and segment size %d", p[0], p[1])
+		}
+	}
+
+	s, err := streaming.New(make([]byte, 16), streaming.DefaultSegmentSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := s.NewEncryptingWriter(io.Discard, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("late")); err == nil {
+		t.Error("wrote after Close")
+	}
+}
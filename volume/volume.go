@@ -0,0 +1,415 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package volume implements a sector-addressable encrypted volume on any
+// io.ReaderAt and io.WriterAt backing store, as a disk image or block
+// device. Each sector is encrypted with AES-XTS under its position, as
+// dm-crypt's aes-xts-plain64: the tweak is the 64-bit little-endian
+// number of the first 512 byte unit of the sector, counted from the
+// start of the volume and padded with zeros, so with larger sectors it
+// steps by SectorSize / 512 as dm-crypt's does without iv_large_sectors.
+// The ciphertext is as long as the plaintext, so sector n of the volume
+// is stored at Offset + n * SectorSize of the backing store.
+//
+// XTS hides the contents of the sectors but does not authenticate them:
+// a modified sector decrypts to garbage, and an older copy of a sector
+// decrypts to its older contents.
+package volume
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cipher"
+)
+
+const (
+	// DefaultSectorSize is the sector size of a Config that leaves it
+	// zero.
+	DefaultSectorSize = 512
+
+	// MinSectorSize and MaxSectorSize bound the sector size, which must
+	// be a power of two.
+	MinSectorSize = 512
+	MaxSectorSize = 4096
+
+	// chunkSize is how much a worker reads or writes at a time, and the
+	// smallest share of an I/O handed to a worker of its own
+	chunkSize = 64 * 1024
+)
+
+var (
+	errOffset = errors.New("volume: negative offset")
+	errEnd    = errors.New("volume: write beyond the end of the volume")
+	errRange  = errors.New("volume: sectors out of range")
+	errLength = errors.New("volume: length not a multiple of the sector size")
+)
+
+// A Backing stores the ciphertext of a volume; *os.File is one. It may be
+// shorter than the volume, as a sparse file: the bytes past its end read
+// as zeros. If it has a Sync method, Flush calls it.
+type Backing interface {
+	io.ReaderAt
+	io.WriterAt
+}
+
+// Config describes the layout of a volume on its backing store.
+type Config struct {
+	// SectorSize is the size of a sector, a power of two from
+	// MinSectorSize to MaxSectorSize; zero means DefaultSectorSize.
+	SectorSize int
+
+	// Offset is where sector 0 starts in the backing store.
+	Offset int64
+
+	// Sectors is the number of sectors of the volume.
+	Sectors int64
+
+	// Parallelism is the number of goroutines that encrypt and decrypt
+	// a large I/O; zero or one does all I/O in the calling goroutine.
+	Parallelism int
+}
+
+// A Volume is an encrypted volume. It is safe for concurrent use, though
+// the result of overlapping concurrent writes is either of them, sector
+// by sector.
+type Volume struct {
+	b           Backing
+	sectorSize  int
+	offset      int64
+	sectors     int64
+	parallelism int
+
+	// workers holds a cipher and buffer for each goroutine doing I/O;
+	// the XTS tweak is state of the cipher, so they cannot be shared
+	workers chan *worker
+
+	// mu serializes the read-modify-write of partial sectors
+	mu sync.Mutex
+}
+
+type worker struct {
+	x     cipher.BlockMode
+	tweak [16]byte
+	buf   []byte
+}
+
+// New returns a volume on b encrypted with key, 32 bytes for AES-128-XTS
+// or 64 bytes for AES-256-XTS, laid out as cfg describes.
+func New(b Backing, key []byte, cfg Config) (*Volume, error) {
+	if len(key) != 32 && len(key) != 64 {
+		return nil, errors.New("volume: invalid key size")
+	}
+	if cfg.SectorSize == 0 {
+		cfg.SectorSize = DefaultSectorSize
+	}
+	if cfg.SectorSize < MinSectorSize || cfg.SectorSize > MaxSectorSize || cfg.SectorSize&(cfg.SectorSize-1) != 0 {
+		return nil, errors.New("volume: invalid sector size")
+	}
+	if cfg.Offset < 0 {
+		return nil, errOffset
+	}
+	if cfg.Sectors <= 0 || cfg.Sectors > (1<<63-1-cfg.Offset)/int64(cfg.SectorSize) {
+		return nil, errors.New("volume: invalid number of sectors")
+	}
+	if cfg.Parallelism < 1 {
+		cfg.Parallelism = 1
+	}
+
+	v := &Volume{
+		b:           b,
+		sectorSize:  cfg.SectorSize,
+		offset:      cfg.Offset,
+		sectors:     cfg.Sectors,
+		parallelism: cfg.Parallelism,
+		workers:     make(chan *worker, cfg.Parallelism),
+	}
+	for i := 0; i < cfg.Parallelism; i++ {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		v.workers <- &worker{x: cipher.NewXTSEncryptor(block), buf: make([]byte, chunkSize)}
+	}
+
+	return v, nil
+}
+
+// SectorSize returns the sector size of v.
+func (v *Volume) SectorSize() int {
+	return v.sectorSize
+}
+
+// Sectors returns the number of sectors of v.
+func (v *Volume) Sectors() int64 {
+	return v.sectors
+}
+
+// Size returns the size of v in bytes.
+func (v *Volume) Size() int64 {
+	return v.sectors * int64(v.sectorSize)
+}
+
+// check validates an I/O of whole sectors.
+func (v *Volume) check(p []byte, sector int64) error {
+	if len(p)%v.sectorSize != 0 {
+		return errLength
+	}
+	if sector < 0 || sector > v.sectors || int64(len(p)/v.sectorSize) > v.sectors-sector {
+		return errRange
+	}
+
+	return nil
+}
+
+// ReadSectors reads and decrypts the sectors starting at sector into p,
+// whose length must be a multiple of the sector size.
+func (v *Volume) ReadSectors(p []byte, sector int64) error {
+	if err := v.check(p, sector); err != nil {
+		return err
+	}
+
+	return v.crypt(p, sector, false)
+}
+
+// WriteSectors encrypts p and writes it to the sectors starting at
+// sector. The length of p must be a multiple of the sector size.
+func (v *Volume) WriteSectors(p []byte, sector int64) error {
+	if err := v.check(p, sector); err != nil {
+		return err
+	}
+
+	return v.crypt(p, sector, true)
+}
+
+// crypt splits an I/O of whole sectors among the workers, at most one
+// per chunkSize bytes.
+func (v *Volume) crypt(p []byte, sector int64, write bool) error {
+	n := (len(p) + chunkSize - 1) / chunkSize
+	if n > v.parallelism {
+		n = v.parallelism
+	}
+	if n <= 1 {
+		return v.run(p, sector, write)
+	}
+
+	share := (len(p)/v.sectorSize + n - 1) / n * v.sectorSize
+	errs := make(chan error, n)
+	var wg sync.WaitGroup
+	for off := 0; off < len(p); off += share {
+		end := off + share
+		if end > len(p) {
+			end = len(p)
+		}
+
+		wg.Add(1)
+		go func(p []byte, sector int64) {
+			defer wg.Done()
+			errs <- v.run(p, sector, write)
+		}(p[off:end], sector+int64(off/v.sectorSize))
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// run does an I/O of whole sectors with one worker, chunkSize bytes at
+// a time. The ciphertext goes through the worker's buffer, so p is left
+// alone on writes.
+func (v *Volume) run(p []byte, sector int64, write bool) error {
+	w := <-v.workers
+	defer func() { v.workers <- w }()
+
+	for len(p) > 0 {
+		n := len(p)
+		if n > len(w.buf) {
+			n = len(w.buf)
+		}
+		buf := w.buf[:n]
+		off := v.offset + sector*int64(v.sectorSize)
+
+		if !write {
+			if err := v.readBacking(buf, off); err != nil {
+				return err
+			}
+		}
+
+		for i := 0; i < n; i += v.sectorSize {
+			binary.LittleEndian.PutUint64(w.tweak[:8], uint64(sector)*uint64(v.sectorSize/512))
+			sector++
+			w.x.SetIV(w.tweak[:])
+
+			var err error
+			if write {
+				err = w.x.Encrypt(buf[i:i+v.sectorSize], p[i:i+v.sectorSize])
+			} else {
+				err = w.x.Decrypt(p[i:i+v.sectorSize], buf[i:i+v.sectorSize])
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if write {
+			if _, err := v.b.WriteAt(buf, off); err != nil {
+				return err
+			}
+		}
+
+		p = p[n:]
+	}
+
+	return nil
+}
+
+// readBacking fills p from the backing store at off, with zeros past its
+// end.
+func (v *Volume) readBacking(p []byte, off int64) error {
+	n, err := v.b.ReadAt(p, off)
+	if err == io.EOF {
+		for i := n; i < len(p); i++ {
+			p[i] = 0
+		}
+		return nil
+	}
+
+	return err
+}
+
+// ReadAt implements io.ReaderAt. A read of partial sectors decrypts the
+// whole sectors.
+func (v *Volume) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, errOffset
+	}
+	size := v.Size()
+	if off >= size {
+		return 0, io.EOF
+	}
+	if int64(len(p)) > size-off {
+		p = p[:size-off]
+		err = io.EOF
+	}
+
+	var buf [MaxSectorSize]byte
+	ss := int64(v.sectorSize)
+	for n < len(p) {
+		q := p[n:]
+		sector, skip := off/ss, int(off%ss)
+
+		if skip == 0 && len(q) >= v.sectorSize {
+			m := len(q) / v.sectorSize * v.sectorSize
+			if err := v.ReadSectors(q[:m], sector); err != nil {
+				return n, err
+			}
+			n += m
+			off += int64(m)
+			continue
+		}
+
+		if err := v.ReadSectors(buf[:v.sectorSize], sector); err != nil {
+			return n, err
+		}
+		m := copy(q, buf[skip:v.sectorSize])
+		n += m
+		off += int64(m)
+	}
+
+	return n, err
+}
+
+// WriteAt implements io.WriterAt. A write of partial sectors reads,
+// updates and rewrites the whole sectors. A write past the end of the
+// volume writes the part that fits and fails.
+func (v *Volume) WriteAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, errOffset
+	}
+	size := v.Size()
+	if int64(len(p)) > size-off {
+		if off >= size {
+			return 0, errEnd
+		}
+		p = p[:size-off]
+		err = errEnd
+	}
+
+	ss := int64(v.sectorSize)
+	for n < len(p) {
+		q := p[n:]
+		sector, skip := off/ss, int(off%ss)
+
+		if skip == 0 && len(q) >= v.sectorSize {
+			m := len(q) / v.sectorSize * v.sectorSize
+			if err := v.WriteSectors(q[:m], sector); err != nil {
+				return n, err
+			}
+			n += m
+			off += int64(m)
+			continue
+		}
+
+		m, err := v.patch(q, sector, skip)
+		if err != nil {
+			return n, err
+		}
+		n += m
+		off += int64(m)
+	}
+
+	return n, err
+}
+
+// patch copies the start of p into sector at skip, returning how many
+// bytes it copied.
+func (v *Volume) patch(p []byte, sector int64, skip int) (int, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	var buf [MaxSectorSize]byte
+	if err := v.ReadSectors(buf[:v.sectorSize], sector); err != nil {
+		return 0, err
+	}
+	n := copy(buf[skip:v.sectorSize], p)
+
+	return n, v.WriteSectors(buf[:v.sectorSize], sector)
+}
+
+// Flush commits the writes to stable storage if the backing store has a
+// Sync method, as *os.File.
+func (v *Volume) Flush() error {
+	if s, ok := v.b.(interface{ Sync() error }); ok {
+		return s.Sync()
+	}
+
+	return nil
+}
@@ -0,0 +1,316 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package volume_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cavp"
+	"github.com/surendarchandra/crypto/dmcrypt"
+	"github.com/surendarchandra/crypto/internal/backingtest"
+	"github.com/surendarchandra/crypto/volume"
+)
+
+// TestVolumeIEEE1619 writes the IEEE 1619 XTS vectors with 512 byte data
+// units to the sector of their tweak and checks the stored ciphertext.
+func TestVolumeIEEE1619(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	count := 0
+	for _, name := range []string{"XTSGenAES128.rsp", "XTSGenAES256.rsp"} {
+		r, err := os.Open(filepath.Join("..", "cavp", "testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := cavp.Parse(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, sec := range f.Sections {
+			if !sec.Has("ENCRYPT") {
+				continue
+			}
+			for _, rec := range sec.Records {
+				if n, _ := rec.Get("DataUnitLen"); n != strconv.Itoa(volume.DefaultSectorSize*8) {
+					continue
+				}
+				key, _ := rec.Get("Key")
+				tweak, _ := rec.Get("i")
+				pt, _ := rec.Get("PT")
+				ct, _ := rec.Get("CT")
+				k, _ := hex.DecodeString(key)
+				i, _ := hex.DecodeString(tweak)
+				plain, _ := hex.DecodeString(pt)
+				want, _ := hex.DecodeString(ct)
+				// Keep the memory backing store small
+				sector := int64(binary.LittleEndian.Uint64(i))
+				if !bytes.Equal(i[8:], make([]byte, 8)) || sector > 1024 {
+					continue
+				}
+
+				m := new(backingtest.Mem)
+				v, err := volume.New(m, k, volume.Config{Offset: 1024, Sectors: sector + 1})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := v.WriteSectors(plain, sector); err != nil {
+					t.Fatal(err)
+				}
+				if got := m.Data[1024+sector*volume.DefaultSectorSize:]; !bytes.Equal(got, want) {
+					t.Errorf("%s sector %d: ciphertext differs", name, sector)
+				}
+
+				got := make([]byte, len(plain))
+				if err := v.ReadSectors(got, sector); err != nil || !bytes.Equal(got, plain) {
+					t.Errorf("%s sector %d: ReadSectors returned %v, plaintext differs: %t", name, sector, err, !bytes.Equal(got, plain))
+				}
+				count++
+			}
+		}
+	}
+	if count < 7 {
+		t.Fatalf("ran %d vectors", count)
+	}
+}
+
+// TestVolume runs random aligned and unaligned reads and writes against
+// a plain copy of the contents, on a file and in memory.
+func TestVolume(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	backings := map[string]func() volume.Backing{
+		"file":   func() volume.Backing { return backingtest.TempFile(t) },
+		"memory": func() volume.Backing { return new(backingtest.Mem) },
+	}
+	r := rand.New(rand.NewSource(1))
+	for name, backing := range backings {
+		for _, cfg := range []volume.Config{
+			{Sectors: 300},
+			{SectorSize: 4096, Offset: 512, Sectors: 40, Parallelism: 4},
+		} {
+			key := make([]byte, 64)
+			r.Read(key)
+			b := backing()
+			v, err := volume.New(b, key, cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v.Size() != cfg.Sectors*int64(v.SectorSize()) {
+				t.Fatalf("%s: size %d", name, v.Size())
+			}
+
+			// Unwritten sectors decrypt zeros
+			model := make([]byte, v.Size())
+			if err := v.ReadSectors(model, 0); err != nil {
+				t.Fatal(err)
+			}
+
+			for op := 0; op < 200; op++ {
+				off := r.Int63n(v.Size())
+				p := make([]byte, r.Intn(5*v.SectorSize()))
+				if r.Intn(2) == 0 {
+					off -= off % int64(v.SectorSize())
+					p = p[:len(p)/v.SectorSize()*v.SectorSize()]
+				}
+				r.Read(p)
+
+				want := len(p)
+				if int64(want) > v.Size()-off {
+					want = int(v.Size() - off)
+				}
+				n, err := v.WriteAt(p, off)
+				if n != want || (err != nil) != (want < len(p)) {
+					t.Fatalf("%s op %d: WriteAt(%d bytes at %d) returned %d, %v", name, op, len(p), off, n, err)
+				}
+				copy(model[off:], p)
+
+				off = r.Int63n(v.Size())
+				p = make([]byte, r.Intn(5*v.SectorSize()))
+				n, err = v.ReadAt(p, off)
+				if n != copy(p, model[off:]) || (err == io.EOF) != (n < len(p)) || !bytes.Equal(p[:n], model[off:off+int64(n)]) {
+					t.Fatalf("%s op %d: ReadAt(%d bytes at %d) returned %d, %v", name, op, len(p), off, n, err)
+				}
+			}
+
+			if err := v.Flush(); err != nil {
+				t.Fatal(err)
+			}
+			v, err = volume.New(b, key, cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make([]byte, v.Size())
+			if n, err := v.ReadAt(got, 0); n != len(got) || err != nil || !bytes.Equal(got, model) {
+				t.Fatalf("%s: reopened volume returned %d, %v; contents differ: %t", name, n, err, !bytes.Equal(got, model))
+			}
+		}
+	}
+}
+
+// TestVolumeDMCrypt checks that the ciphertext of each sector size is
+// dm-crypt's aes-xts-plain64, whose IV counts 512 byte units.
+func TestVolumeDMCrypt(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for _, sectorSize := range []int{512, 4096} {
+		key := make([]byte, 64)
+		r.Read(key)
+		m := new(backingtest.Mem)
+		v, err := volume.New(m, key, volume.Config{SectorSize: sectorSize, Sectors: 8})
+		if err != nil {
+			t.Fatal(err)
+		}
+		plain := make([]byte, v.Size())
+		r.Read(plain)
+		if err := v.WriteSectors(plain, 0); err != nil {
+			t.Fatal(err)
+		}
+
+		sc, err := dmcrypt.NewSectorCipher("aes-xts-plain64", key, sectorSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := make([]byte, len(plain))
+		if err := sc.Encrypt(want, plain, 0); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(m.Data, want) {
+			t.Errorf("%d byte sectors: ciphertext differs from dm-crypt's", sectorSize)
+		}
+
+		// A single sector lands at its 512 byte unit
+		sector := make([]byte, sectorSize)
+		if err := sc.Encrypt(sector, plain[5*sectorSize:6*sectorSize], uint64(5*sectorSize/512)); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(m.Data[5*sectorSize:6*sectorSize], sector) {
+			t.Errorf("%d byte sectors: sector 5 differs from dm-crypt's", sectorSize)
+		}
+	}
+}
+
+// TestVolumeParallel checks large I/Os split among goroutines store the
+// same ciphertext as I/Os in one goroutine.
+func TestVolumeParallel(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	key := make([]byte, 32)
+	data := make([]byte, 1<<20+3*512)
+	rand.New(rand.NewSource(2)).Read(data)
+
+	serial, parallel := new(backingtest.Mem), new(backingtest.Mem)
+	vs, err := volume.New(serial, key, volume.Config{Sectors: 4096})
+	if err != nil {
+		t.Fatal(err)
+	}
+	vp, err := volume.New(parallel, key, volume.Config{Sectors: 4096, Parallelism: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := vs.WriteSectors(data, 7); err != nil {
+		t.Fatal(err)
+	}
+	if err := vp.WriteSectors(data, 7); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(serial.Data, parallel.Data) {
+		t.Fatal("parallel ciphertext differs")
+	}
+
+	got := make([]byte, len(data))
+	if err := vp.ReadSectors(got, 7); err != nil || !bytes.Equal(got, data) {
+		t.Fatalf("ReadSectors returned %v; plaintext differs: %t", err, !bytes.Equal(got, data))
+	}
+}
+
+func TestVolumeErrors(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	m := new(backingtest.Mem)
+	for _, c := range []struct {
+		keySize int
+		cfg     volume.Config
+	}{
+		{16, volume.Config{Sectors: 1}},
+		{48, volume.Config{Sectors: 1}},
+		{32, volume.Config{Sectors: 0}},
+		{32, volume.Config{Sectors: 1, SectorSize: 256}},
+		{32, volume.Config{Sectors: 1, SectorSize: 1536}},
+		{32, volume.Config{Sectors: 1, SectorSize: 8192}},
+		{32, volume.Config{Sectors: 1, Offset: -1}},
+		{32, volume.Config{Sectors: 1 << 55}},
+	} {
+		if _, err := volume.New(m, make([]byte, c.keySize), c.cfg); err == nil {
+			t.Errorf("New accepted a %d byte key with %+v", c.keySize, c.cfg)
+		}
+	}
+
+	v, err := volume.New(m, make([]byte, 32), volume.Config{Sectors: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := make([]byte, 512)
+	if err := v.ReadSectors(p[:100], 0); err == nil {
+		t.Error("ReadSectors accepted a partial sector")
+	}
+	if err := v.WriteSectors(p, 4); err == nil {
+		t.Error("WriteSectors accepted a sector past the end")
+	}
+	if err := v.WriteSectors(p, -1); err == nil {
+		t.Error("WriteSectors accepted a negative sector")
+	}
+	if _, err := v.ReadAt(p, -1); err == nil {
+		t.Error("ReadAt accepted a negative offset")
+	}
+	if n, err := v.ReadAt(p, v.Size()); n != 0 || err != io.EOF {
+		t.Errorf("ReadAt at the end returned %d, %v", n, err)
+	}
+	if n, err := v.WriteAt(p, v.Size()-10); n != 10 || err == nil {
+		t.Errorf("WriteAt across the end returned %d, %v", n, err)
+	}
+	if int64(len(m.Data)) != v.Size() {
+		t.Errorf("backing store grew to %d bytes", len(m.Data))
+	}
+}
@@ -0,0 +1,136 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package xaes256gcm implements XAES-256-GCM, an AEAD with a 192-bit
+// nonce, as specified at https://c2sp.org/XAES-256-GCM.
+//
+// Each message is sealed with AES-256-GCM under a key derived from the
+// first half of the nonce, so random nonces can be used for an
+// effectively unlimited number of messages under one key.
+package xaes256gcm
+
+import (
+	"errors"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/cipher"
+	"github.com/surendarchandra/crypto/internal/gf128"
+)
+
+const (
+	// KeySize is the size of the XAES-256-GCM key.
+	KeySize = 32
+
+	// NonceSize is the size of the XAES-256-GCM nonce.
+	NonceSize = 24
+
+	// Overhead is the size of the GCM tag.
+	Overhead = 16
+)
+
+type xaes struct {
+	block cipher.Block
+	k1    [aes.BlockSize]byte
+}
+
+// New returns an XAES-256-GCM AEAD for a 32 byte key. It is safe for
+// concurrent use.
+func New(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, errors.New("xaes256gcm: invalid key size")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	x := &xaes{block: block}
+
+	// K1 is the CMAC subkey: L = AES(K, 0^128) doubled in GF(2^128)
+	var l [aes.BlockSize]byte
+	if err := cipher.EncryptBlock(x.block, l[:], l[:]); err != nil {
+		return nil, err
+	}
+	x.k1 = gf128.Double(l)
+
+	return x, nil
+}
+
+// deriveKey returns the AES-256-GCM AEAD for the first 12 bytes of the
+// nonce: the key is AES(K, K1 ^ (0x0001 'X' 0x00 || N)) followed by
+// AES(K, K1 ^ (0x0002 'X' 0x00 || N)).
+func (x *xaes) deriveKey(nonce []byte) (cipher.AEAD, error) {
+	var m [2 * aes.BlockSize]byte
+	m[1], m[2] = 1, 'X'
+	copy(m[4:16], nonce)
+	m[17], m[18] = 2, 'X'
+	copy(m[20:32], nonce)
+	for i := range m {
+		m[i] ^= x.k1[i%aes.BlockSize]
+	}
+
+	if err := cipher.EncryptBlocks(x.block, m[:], m[:]); err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(m[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func (*xaes) NonceSize() int {
+	return NonceSize
+}
+
+func (*xaes) Overhead() int {
+	return Overhead
+}
+
+func (x *xaes) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != NonceSize {
+		panic("xaes256gcm: incorrect nonce length given to XAES-256-GCM")
+	}
+
+	g, err := x.deriveKey(nonce[:12])
+	if err != nil {
+		panic(err)
+	}
+
+	return g.Seal(dst, nonce[12:], plaintext, additionalData)
+}
+
+func (x *xaes) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != NonceSize {
+		panic("xaes256gcm: incorrect nonce length given to XAES-256-GCM")
+	}
+
+	g, err := x.deriveKey(nonce[:12])
+	if err != nil {
+		return nil, err
+	}
+
+	return g.Open(dst, nonce[12:], ciphertext, additionalData)
+}
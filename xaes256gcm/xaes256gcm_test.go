@@ -0,0 +1,120 @@
+// MIT License
+//
+// Copyright (c) 2017 Surendar Chandra
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package xaes256gcm_test
+
+import (
+	"bytes"
+	"crypto/sha3"
+	"encoding/hex"
+	"testing"
+
+	"github.com/surendarchandra/crypto/aes"
+	"github.com/surendarchandra/crypto/xaes256gcm"
+)
+
+// Test vectors from https://c2sp.org/XAES-256-GCM
+func TestVectors(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	for _, tc := range []struct {
+		key       byte
+		aad, want string
+	}{
+		{0x01, "", "ce546ef63c9cc60765923609b33a9a1974e96e52daf2fcf7075e2271"},
+		{0x03, "c2sp.org/XAES-256-GCM", "986ec1832593df5443a179437fd083bf3fdb41abd740a21f71eb769d"},
+	} {
+		aead, err := xaes256gcm.New(bytes.Repeat([]byte{tc.key}, xaes256gcm.KeySize))
+		if err != nil {
+			t.Fatal(err)
+		}
+		nonce := []byte("ABCDEFGHIJKLMNOPQRSTUVWX")
+		plaintext := []byte("XAES-256-GCM")
+
+		ct := aead.Seal(nil, nonce, plaintext, []byte(tc.aad))
+		if got := hex.EncodeToString(ct); got != tc.want {
+			t.Errorf("key %#x: got %s, want %s", tc.key, got, tc.want)
+		}
+
+		pt, err := aead.Open(nil, nonce, ct, []byte(tc.aad))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(pt, plaintext) {
+			t.Errorf("key %#x: got %q, want %q", tc.key, pt, plaintext)
+		}
+
+		ct[0] ^= 1
+		if _, err := aead.Open(nil, nonce, ct, []byte(tc.aad)); err == nil {
+			t.Errorf("key %#x: opened a modified ciphertext", tc.key)
+		}
+	}
+}
+
+// The accumulated vector of the spec: inputs are read from a SHAKE128
+// stream and the ciphertexts are hashed with another.
+func TestAccumulated(t *testing.T) {
+	if !aes.IsSupported() {
+		t.Skip("AES-NI not supported")
+	}
+
+	const iterations = 10000
+	const want = "e6b9edf2df6cec60c8cbd864e2211b597fb69a529160cd040d56c0c210081939"
+
+	s, d := sha3.NewSHAKE128(), sha3.NewSHAKE128()
+	for i := 0; i < iterations; i++ {
+		key := make([]byte, xaes256gcm.KeySize)
+		s.Read(key)
+		nonce := make([]byte, xaes256gcm.NonceSize)
+		s.Read(nonce)
+		n := make([]byte, 1)
+		s.Read(n)
+		plaintext := make([]byte, int(n[0]))
+		s.Read(plaintext)
+		s.Read(n)
+		aad := make([]byte, int(n[0]))
+		s.Read(aad)
+
+		aead, err := xaes256gcm.New(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ct := aead.Seal(nil, nonce, plaintext, aad)
+		pt, err := aead.Open(nil, nonce, ct, aad)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(pt, plaintext) {
+			t.Fatalf("iteration %d: round trip failed", i)
+		}
+
+		d.Write(ct)
+	}
+
+	sum := make([]byte, 32)
+	d.Read(sum)
+	if got := hex.EncodeToString(sum); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}